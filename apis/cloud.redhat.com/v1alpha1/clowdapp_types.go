@@ -25,6 +25,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -75,6 +76,99 @@ type DatabaseSpec struct {
 	// T-shirt size, one of small, medium, large
 	// +kubebuilder:validation:Enum={"small", "medium", "large"}
 	DBResourceSize string `json:"dbResourceSize,omitempty"`
+
+	// Defines a source of SQL which is loaded into the database once it has
+	// been created. Only used in (*_local_*) and (*_shared_*) modes.
+	Seed *DatabaseSeed `json:"seed,omitempty"`
+
+	// Gives the app the admin credentials of the database server as well as
+	// the credentials of its own role. Only used in (*_shared_*) mode, where
+	// every app otherwise only has access to its own database.
+	AdminCredentials bool `json:"adminCredentials,omitempty"`
+
+	// Puts a PgBouncer connection pooler in front of the database and points
+	// the app config at it. In (*_local_*) and (*_shared_*) modes the pooler is
+	// deployed next to the app, in (*_app-interface_*) mode it is read from a
+	// secret annotated with clowder/database-pooler.
+	Pooler *DatabasePooler `json:"pooler,omitempty"`
+
+	// The number of streaming read replicas to run next to the database, which
+	// are given to the app as read replicas. Only used in (*_local_*) mode.
+	// +kubebuilder:validation:Minimum=0
+	ReadReplicas int32 `json:"readReplicas,omitempty"`
+
+	// The database engine, one of postgresql, mysql or mongodb, defaults to
+	// postgresql. The version, seed, pooler and read replicas are only
+	// supported by postgresql, the other engines are only available in
+	// (*_local_*) and (*_app-interface_*) modes.
+	// +kubebuilder:validation:Enum={"postgresql", "mysql", "mongodb"}
+	Engine string `json:"engine,omitempty"`
+}
+
+// DatabasePooler defines the settings of a PgBouncer connection pooler.
+type DatabasePooler struct {
+	// The pool mode, one of session, transaction or statement, defaults to
+	// transaction.
+	// +kubebuilder:validation:Enum={"session", "transaction", "statement"}
+	Mode string `json:"mode,omitempty"`
+
+	// The number of server connections kept for each user and database pair,
+	// defaults to 20.
+	// +kubebuilder:validation:Minimum=1
+	PoolSize int32 `json:"poolSize,omitempty"`
+
+	// The maximum number of client connections, defaults to 100.
+	// +kubebuilder:validation:Minimum=1
+	MaxClientConnections int32 `json:"maxClientConnections,omitempty"`
+}
+
+// MigrationsSpec defines the job which migrates the database schema of a
+// ClowdApp.
+type MigrationsSpec struct {
+	// The name of a job in the jobs list of the ClowdApp, which must not have a
+	// schedule. It is run once for every change of its image.
+	JobName string `json:"jobName"`
+}
+
+// DatabaseSeed defines where the SQL used to seed a database is read from,
+// exactly one source must be set. The seed is applied once, a change to the
+// seed source applies the new seed on top of the existing data.
+type DatabaseSeed struct {
+	// A ConfigMap in the namespace of the ClowdApp holding the SQL.
+	ConfigMap *DatabaseSeedConfigMap `json:"configMap,omitempty"`
+
+	// A file on a PersistentVolumeClaim in the namespace of the ClowdApp.
+	PVC *DatabaseSeedPVC `json:"pvc,omitempty"`
+
+	// An object in a bucket of the MinIO object store of the ClowdEnvironment.
+	ObjectStore *DatabaseSeedObjectStore `json:"objectStore,omitempty"`
+}
+
+// DatabaseSeedConfigMap references a key of a ConfigMap holding SQL.
+type DatabaseSeedConfigMap struct {
+	// Name of the ConfigMap.
+	Name string `json:"name"`
+
+	// Key of the ConfigMap holding the SQL, defaults to seed.sql.
+	Key string `json:"key,omitempty"`
+}
+
+// DatabaseSeedPVC references a SQL file on a PersistentVolumeClaim.
+type DatabaseSeedPVC struct {
+	// Name of the PersistentVolumeClaim.
+	ClaimName string `json:"claimName"`
+
+	// Path of the SQL file on the volume.
+	Path string `json:"path"`
+}
+
+// DatabaseSeedObjectStore references a SQL file in an object store bucket.
+type DatabaseSeedObjectStore struct {
+	// Name of the bucket.
+	Bucket string `json:"bucket"`
+
+	// Key of the object holding the SQL.
+	Key string `json:"key"`
 }
 
 // Job defines a ClowdJob
@@ -187,6 +281,31 @@ type DeploymentMetadata struct {
 	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
+// WorkloadKind defines the kind of k8s resource a Deployment is rendered as, one of
+// 'Deployment' or 'StatefulSet'
+// +kubebuilder:validation:Enum={"Deployment", "StatefulSet", ""}
+type WorkloadKind string
+
+const (
+	// WorkloadKindDeployment renders the Deployment as an apps.Deployment (the default)
+	WorkloadKindDeployment WorkloadKind = "Deployment"
+	// WorkloadKindStatefulSet renders the Deployment as an apps.StatefulSet
+	WorkloadKindStatefulSet WorkloadKind = "StatefulSet"
+)
+
+// StatefulSetSpec defines the options that only apply when a Deployment is rendered as a
+// StatefulSet.
+type StatefulSetSpec struct {
+	// A list of PersistentVolumeClaims in standard k8s format, a PVC will be created for each
+	// replica from each template. The claims can be mounted into the pod by referencing the
+	// template name in the PodSpec volumeMounts.
+	VolumeClaimTemplates []v1.PersistentVolumeClaim `json:"volumeClaimTemplates,omitempty"`
+
+	// Controls how pods are created during initial scale up, when replacing pods on nodes, or
+	// when scaling down, defaults to OrderedReady.
+	PodManagementPolicy apps.PodManagementPolicyType `json:"podManagementPolicy,omitempty"`
+}
+
 // Deployment defines a service running inside a ClowdApp and will output a deployment resource.
 // Only one container per pod is allowed and this is defined in the PodSpec attribute.
 type Deployment struct {
@@ -226,6 +345,39 @@ type Deployment struct {
 	DeploymentStrategy *DeploymentStrategy `json:"deploymentStrategy,omitempty"`
 
 	Metadata DeploymentMetadata `json:"metadata,omitempty"`
+
+	// WorkloadKind selects the kind of resource the deployment is rendered as, either a
+	// Deployment or a StatefulSet, defaults to Deployment. A StatefulSet is given stable
+	// network identities through a headless service named <app>-<deployment>-headless.
+	WorkloadKind WorkloadKind `json:"workloadKind,omitempty"`
+
+	// StatefulSet defines options that are only used when WorkloadKind is set to StatefulSet.
+	StatefulSet *StatefulSetSpec `json:"statefulSet,omitempty"`
+
+	// PodDisruptionBudget overrides the PodDisruptionBudget policy of the environment for
+	// this deployment.
+	PodDisruptionBudget *DeploymentPodDisruptionBudget `json:"podDisruptionBudget,omitempty"`
+}
+
+// DeploymentPodDisruptionBudget overrides the PodDisruptionBudget created for a deployment.
+type DeploymentPodDisruptionBudget struct {
+	// Enabled overrides whether a PodDisruptionBudget is created for the deployment. If
+	// unset, the policy of the environment is used. A PodDisruptionBudget is never created
+	// for a deployment which can only run a single replica.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// MaxUnavailable is the number or percentage of pods which can be unavailable during a
+	// disruption, defaults to the value set in the environment.
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// MinAvailable is the number or percentage of pods which must remain available during a
+	// disruption. It cannot be used together with MaxUnavailable.
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+}
+
+// IsStatefulSet returns true if the deployment is to be rendered as a StatefulSet.
+func (d *Deployment) IsStatefulSet() bool {
+	return d.WorkloadKind == WorkloadKindStatefulSet
 }
 
 func (d *Deployment) GetReplicaCount() *int32 {
@@ -248,13 +400,66 @@ type DeploymentStrategy struct {
 }
 
 type Sidecar struct {
-	// The name of the sidecar, only supported names allowed, (token-refresher)
+	// The name of the sidecar. Either one of the built-in sidecars, (token-refresher),
+	// or the name of a user defined sidecar container, in which case an image must
+	// also be supplied.
 	Name string `json:"name"`
 
 	// Defines if the sidecar is enabled, defaults to False
 	Enabled bool `json:"enabled"`
+
+	// Image refers to the container image used to create a user defined
+	// sidecar. Must not be set for built-in sidecars.
+	Image string `json:"image,omitempty"`
+
+	// The command that will be invoked inside the sidecar at startup.
+	Command []string `json:"command,omitempty"`
+
+	// A list of args to be passed to the sidecar container.
+	Args []string `json:"args,omitempty"`
+
+	// A list of environment variables used only by the sidecar container.
+	Env []v1.EnvVar `json:"env,omitempty"`
+
+	// A pass-through of a resource requirements in k8s ResourceRequirements
+	// format. If omitted, the default resource requirements from the
+	// ClowdEnvironment will be used.
+	Resources v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// A pass-through of a Liveness Probe specification in standard k8s format.
+	LivenessProbe *v1.Probe `json:"livenessProbe,omitempty"`
+
+	// A pass-through of a Readiness Probe specification in standard k8s format.
+	ReadinessProbe *v1.Probe `json:"readinessProbe,omitempty"`
+
+	// A pass-through of a list of VolumesMounts in standard k8s format, the
+	// volumes themselves must be defined in the parent PodSpec.
+	VolumeMounts []v1.VolumeMount `json:"volumeMounts,omitempty"`
+
+	// If true, the sidecar will have the cdappconfig mounted and the ACG_CONFIG
+	// environment variable set, in the same way as the primary container.
+	InheritConfig bool `json:"inheritConfig,omitempty"`
 }
 
+// IsBuiltIn returns true if the sidecar is one of the sidecars provided by
+// Clowder itself, rather than a user defined container.
+func (s *Sidecar) IsBuiltIn() bool {
+	for _, name := range BuiltInSidecars {
+		if s.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// BuiltInSidecars lists the names of the sidecars that Clowder knows how to
+// render without an image being supplied.
+var BuiltInSidecars = []string{"token-refresher"}
+
+// ReservedContainerNames lists the names of containers that Clowder may inject
+// into a pod, and which therefore cannot be used by user defined sidecars.
+var ReservedContainerNames = []string{"crcauth", "envoy-tls"}
+
 // Metadata for applying annotations etc to PodSpec
 type PodspecMetadata struct {
 	Annotations map[string]string `json:"annotations,omitempty"`
@@ -308,6 +513,24 @@ type PodSpec struct {
 
 	// MachinePool allows the pod to be scheduled to a particular machine pool.
 	MachinePool string `json:"machinePool,omitempty"`
+
+	// Affinity defines the scheduling constraints of the pod, it replaces the default pod
+	// anti affinity which spreads replicas across zones and nodes.
+	Affinity *v1.Affinity `json:"affinity,omitempty"`
+
+	// TopologySpreadConstraints defines how the pods are spread across topology domains. A
+	// constraint without a labelSelector selects the pods of the deployment. If unset, the
+	// default constraints of the environment are used for deployments.
+	TopologySpreadConstraints []v1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// Tolerations are added to the pod, alongside the toleration for the MachinePool.
+	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
+
+	// PriorityClassName sets the priority class of the pod.
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// NodeSelector restricts the nodes the pod can be scheduled to.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
 }
 
 // SimpleAutoScalerMetric defines a metric of either a value or utilization
@@ -402,6 +625,95 @@ type KafkaTopicSpec struct {
 	// +kubebuilder:validation:MaxLength:=249
 	// +kubebuilder:validation:Pattern:="[a-zA-Z0-9\\._\\-]"
 	TopicName string `json:"topicName"`
+
+	// Whether the app produces to, consumes from, or does both with this
+	// topic. If unset, the app is assumed to do both.
+	// +optional
+	// +kubebuilder:validation:Enum={"producer", "consumer", "both"}
+	Role string `json:"role,omitempty"`
+
+	// The consumer group the app consumes this topic with. Can not be set when
+	// the role is producer.
+	// +optional
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=249
+	ConsumerGroup string `json:"consumerGroup,omitempty"`
+
+	// The policy for this topic once no app in the environment declares it
+	// anymore, overriding the topicDeletionPolicy of the ClowdEnvironment. When
+	// the apps declaring a topic set different policies, the topic is kept the
+	// longest of them.
+	// +optional
+	DeletionPolicy TopicDeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// When set, a dead letter topic is created along with this topic, named
+	// after it with the '.dlq' suffix unless another suffix is given.
+	// +optional
+	DeadLetter *KafkaCompanionTopicSpec `json:"deadLetter,omitempty"`
+
+	// When set, a retry topic is created along with this topic, named after it
+	// with the '.retry' suffix unless another suffix is given.
+	// +optional
+	Retry *KafkaCompanionTopicSpec `json:"retry,omitempty"`
+
+	// The name of the Kafka cluster of the ClowdEnvironment this topic is
+	// placed on. If unset, the topic is placed on the default cluster. The dead
+	// letter and retry topics are placed on the same cluster.
+	// +optional
+	Cluster string `json:"cluster,omitempty"`
+}
+
+// KafkaCompanionTopicSpec defines a dead letter or retry topic created along
+// with a KafkaTopicSpec. Unset values are taken from the topic.
+type KafkaCompanionTopicSpec struct {
+	// The suffix appended to the name of the topic to name the companion topic.
+	// +optional
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=32
+	// +kubebuilder:validation:Pattern:="^[a-zA-Z0-9\\._\\-]+$"
+	Suffix string `json:"suffix,omitempty"`
+
+	// A key/value pair describing the configuration of the companion topic.
+	// +optional
+	Config map[string]string `json:"config,omitempty"`
+
+	// The requested number of partitions for the companion topic.
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=200000
+	Partitions int32 `json:"partitions,omitempty"`
+
+	// The requested number of replicas for the companion topic.
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=32767
+	Replicas int32 `json:"replicas,omitempty"`
+}
+
+// KafkaConnectorSpec defines a Kafka Connect connector run for the ClowdApp
+type KafkaConnectorSpec struct {
+	// The name of the connector, unique within the ClowdApp.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=63
+	// +kubebuilder:validation:Pattern:="^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+	Name string `json:"name"`
+
+	// The Java class of the connector.
+	// +kubebuilder:validation:MinLength:=1
+	Class string `json:"class"`
+
+	// The maximum number of tasks the connector runs. If unset, the default of
+	// the connector is used.
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+	TasksMax int32 `json:"tasksMax,omitempty"`
+
+	// A key/value pair describing the configuration of the connector. Values
+	// are Go templates which can refer to the database of the app, as in
+	// '{{ .Database.Hostname }}', and to the names of its topics, as in
+	// '{{ topic "requested-name" }}'.
+	// +optional
+	Config map[string]string `json:"config,omitempty"`
 }
 
 type TestingSpec struct {
@@ -417,6 +729,10 @@ type ClowdAppSpec struct {
 	// A list of jobs
 	Jobs []Job `json:"jobs,omitempty"`
 
+	// Defines a job which migrates the database schema of the app, the
+	// deployments are only updated once it has succeeded for their image.
+	Migrations *MigrationsSpec `json:"migrations,omitempty"`
+
 	// The name of the ClowdEnvironment resource that this ClowdApp will use as
 	// its base. This does not mean that the ClowdApp needs to be placed in the
 	// same directory as the targetNamespace of the ClowdEnvironment.
@@ -426,6 +742,12 @@ type ClowdAppSpec struct {
 	// the pods listed in the ClowdApp.
 	KafkaTopics []KafkaTopicSpec `json:"kafkaTopics,omitempty"`
 
+	// A list of Kafka Connect connectors run for the app. When the app's
+	// ClowdEnvironment has the kafka provider set to (*_operator_*) mode,
+	// Clowder will create a KafkaConnector for each of them in the
+	// environment's kafka-connect namespace.
+	KafkaConnectors []KafkaConnectorSpec `json:"kafkaConnectors,omitempty"`
+
 	// The database specification defines a single database, the configuration
 	// of which will be made available to all the pods in the ClowdApp.
 	Database DatabaseSpec `json:"database,omitempty"`
@@ -476,6 +798,12 @@ const (
 	ReconciliationFailed clusterv1.ConditionType = "ReconciliationFailed"
 	// JobInvocationComplete means all the Jobs have finished
 	JobInvocationComplete clusterv1.ConditionType = "JobInvocationComplete"
+	// DatabaseSeeded means the database of the app has been seeded
+	DatabaseSeeded clusterv1.ConditionType = "DatabaseSeeded"
+	// MigrationsApplied means the migrations have been applied for the current image
+	MigrationsApplied clusterv1.ConditionType = "MigrationsApplied"
+	// KafkaTopicsMatch means the existing KafkaTopics match the spec the app requests
+	KafkaTopicsMatch clusterv1.ConditionType = "KafkaTopicsMatch"
 )
 
 // ClowdAppStatus defines the observed state of ClowdApp
@@ -486,19 +814,141 @@ type ClowdAppStatus struct {
 	Deployments AppResourceStatus     `json:"deployments,omitempty"`
 	Ready       bool                  `json:"ready"`
 	Conditions  []clusterv1.Condition `json:"conditions,omitempty"`
+
+	// The state of the migrations of the ClowdApp.
+	Migrations *MigrationStatus `json:"migrations,omitempty"`
+
+	// The state of the Kafka Connect connectors of the ClowdApp.
+	KafkaConnectors []KafkaConnectorStatus `json:"kafkaConnectors,omitempty"`
+
+	// The differences between the Kafka topics the ClowdApp requests and the
+	// existing KafkaTopics, in app-interface mode.
+	KafkaTopicMismatches []KafkaTopicMismatch `json:"kafkaTopicMismatches,omitempty"`
+}
+
+// KafkaTopicMismatch describes how an existing KafkaTopic differs from the
+// spec a ClowdApp requests for it.
+type KafkaTopicMismatch struct {
+	// The requested name of the topic.
+	TopicName string `json:"topicName"`
+
+	// The field of the topic which differs, such as partitions, replicas or a
+	// config key.
+	Field string `json:"field"`
+
+	// The value the ClowdApp requests.
+	Requested string `json:"requested,omitempty"`
+
+	// The value of the existing KafkaTopic, empty when it is not set.
+	Actual string `json:"actual,omitempty"`
+}
+
+// KafkaConnectorStatus describes the state of a Kafka Connect connector of a
+// ClowdApp.
+type KafkaConnectorStatus struct {
+	// The name of the connector in the ClowdApp.
+	Name string `json:"name"`
+
+	// The name of the KafkaConnector resource.
+	ConnectorName string `json:"connectorName,omitempty"`
+
+	// Whether the connector is ready.
+	Ready bool `json:"ready"`
+
+	// The state of the connector as reported by Kafka Connect, such as
+	// RUNNING, PAUSED or FAILED.
+	State string `json:"state,omitempty"`
+
+	// A message describing why the connector is not ready.
+	Message string `json:"message,omitempty"`
+}
+
+// MigrationStatus describes the state of the migrations of a ClowdApp.
+type MigrationStatus struct {
+	// The name of the job running the migrations for the current image.
+	JobName string `json:"jobName,omitempty"`
+
+	// The image the migrations are run for.
+	Image string `json:"image,omitempty"`
+
+	// The phase of the migrations for the current image, one of Running,
+	// Succeeded or Failed.
+	Phase string `json:"phase,omitempty"`
+
+	// A message describing why the migrations failed.
+	Message string `json:"message,omitempty"`
+
+	// The image the migrations were last applied for.
+	AppliedImage string `json:"appliedImage,omitempty"`
+
+	// The time the migrations were last applied.
+	AppliedTime *metav1.Time `json:"appliedTime,omitempty"`
 }
 
 type AppResourceStatus struct {
 	ManagedDeployments int32 `json:"managedDeployments"`
 	ReadyDeployments   int32 `json:"readyDeployments"`
+
+	// A detailed status of each Deployment or StatefulSet managed by the ClowdApp.
+	DeploymentStatuses []DeploymentStatus `json:"deploymentStatuses,omitempty"`
+
+	// A detailed status of each CronJob managed by the ClowdApp.
+	CronJobStatuses []CronJobStatus `json:"cronJobStatuses,omitempty"`
+}
+
+// DeploymentStatus describes the rollout state of a single Deployment or StatefulSet.
+type DeploymentStatus struct {
+	// The name of the Deployment or StatefulSet resource.
+	Name string `json:"name"`
+
+	// The kind of the resource, either Deployment or StatefulSet.
+	Kind string `json:"kind"`
+
+	// Whether the resource has finished rolling out and is available.
+	Ready bool `json:"ready"`
+
+	// The number of replicas requested in the spec.
+	DesiredReplicas int32 `json:"desiredReplicas"`
+
+	// The number of replicas which are ready.
+	ReadyReplicas int32 `json:"readyReplicas"`
+
+	// The number of replicas running the latest pod template.
+	UpdatedReplicas int32 `json:"updatedReplicas"`
+
+	// The image currently set on the application container.
+	Image string `json:"image,omitempty"`
+
+	// The message of the most recently updated rollout condition.
+	Message string `json:"message,omitempty"`
+
+	// The generation most recently observed by the workload controller.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// CronJobStatus describes the schedule state of a single CronJob.
+type CronJobStatus struct {
+	// The name of the CronJob resource.
+	Name string `json:"name"`
+
+	// The last time the CronJob was scheduled.
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// The last time a job created by the CronJob completed successfully.
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
+
+	// The number of jobs created by the CronJob which are running.
+	Active int32 `json:"active,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:shortName=app
+// +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="Ready",type="integer",JSONPath=".status.deployments.readyDeployments"
 // +kubebuilder:printcolumn:name="Managed",type="integer",JSONPath=".status.deployments.managedDeployments"
 // +kubebuilder:printcolumn:name="EnvName",type="string",JSONPath=".spec.envName"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"DeploymentsReady\")].message"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // ClowdApp is the Schema for the clowdapps API
@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -29,6 +30,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
 
+// DefaultDatabaseVersion is the PostgreSQL version used if a ClowdApp does not request one
+const DefaultDatabaseVersion int32 = 12
+
+// maxTopicNameLength is the longest name of a topic Kafka accepts
+const maxTopicNameLength = 249
+
 // log is for logging in this package.
 var clowdapplog = logf.Log.WithName("clowdapp-resource")
 
@@ -38,9 +45,60 @@ func (r *ClowdApp) SetupWebhookWithManager(mgr ctrl.Manager) error {
 		Complete()
 }
 
-//+kubebuilder:webhook:path=/validate-cloud-redhat-com-v1alpha1-clowdapp,mutating=false,failurePolicy=fail,sideEffects=None,groups=cloud.redhat.com,resources=clowdapps,verbs=create;update,versions=v1alpha1,name=vclowdapp.kb.io,admissionReviewVersions={v1}
 //+kubebuilder:webhook:path=/mutate-pod,mutating=true,failurePolicy=ignore,sideEffects=None,groups="",resources=pods,verbs=create;update,versions=v1,name=vclowdmutatepod.kb.io,admissionReviewVersions={v1}
 
+//+kubebuilder:webhook:path=/mutate-cloud-redhat-com-v1alpha1-clowdapp,mutating=true,failurePolicy=fail,sideEffects=None,groups=cloud.redhat.com,resources=clowdapps,verbs=create;update,versions=v1alpha1,name=mclowdapp.kb.io,admissionReviewVersions={v1}
+
+var _ webhook.Defaulter = &ClowdApp{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type. The defaults
+// written here match the values the providers fall back to, so that the stored spec shows the
+// effective configuration.
+func (r *ClowdApp) Default() {
+	clowdapplog.Info("default", "name", r.Name)
+
+	for i := range r.Spec.Deployments {
+		deployment := &r.Spec.Deployments[i]
+
+		// The deprecated MinReplicas field is left alone so that it keeps taking effect
+		if deployment.Replicas == nil && deployment.MinReplicas == nil {
+			deployment.Replicas = deployment.GetReplicaCount()
+		}
+
+		// A strategy defaulted before the deployment became a StatefulSet is dropped, as it is not
+		// removed from the stored spec when the user applies the new workload kind
+		if deployment.IsStatefulSet() && deployment.DeploymentStrategy != nil &&
+			deployment.DeploymentStrategy.PrivateStrategy == apps.RollingUpdateDeploymentStrategyType {
+			deployment.DeploymentStrategy = nil
+		}
+
+		if !deployment.IsStatefulSet() && !deployment.WebServices.Public.Enabled && !bool(deployment.Web) {
+			if deployment.DeploymentStrategy == nil {
+				deployment.DeploymentStrategy = &DeploymentStrategy{}
+			}
+			if deployment.DeploymentStrategy.PrivateStrategy == "" {
+				deployment.DeploymentStrategy.PrivateStrategy = apps.RollingUpdateDeploymentStrategyType
+			}
+		}
+	}
+
+	for i := range r.Spec.Jobs {
+		job := &r.Spec.Jobs[i]
+		if job.RestartPolicy == "" {
+			job.RestartPolicy = core.RestartPolicyNever
+		}
+	}
+
+	// Only postgresql databases have a version to choose
+	engine := r.Spec.Database.Engine
+	if r.Spec.Database.Name != "" && r.Spec.Database.Version == nil && (engine == "" || engine == "postgresql") {
+		version := DefaultDatabaseVersion
+		r.Spec.Database.Version = &version
+	}
+}
+
+//+kubebuilder:webhook:path=/validate-cloud-redhat-com-v1alpha1-clowdapp,mutating=false,failurePolicy=fail,sideEffects=None,groups=cloud.redhat.com,resources=clowdapps,verbs=create;update,versions=v1alpha1,name=vclowdapp.kb.io,admissionReviewVersions={v1}
+
 var _ webhook.Validator = &ClowdApp{}
 
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
@@ -52,18 +110,29 @@ func (r *ClowdApp) ValidateCreate() error {
 		validateSidecars,
 		validateInit,
 		validateDeploymentStrategy,
+		validateWorkloadKind,
+		validatePodDisruptionBudget,
+		validateMigrations,
+		validateKafkaTopics,
+		validateKafkaConnectors,
 	)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
-func (r *ClowdApp) ValidateUpdate(_ runtime.Object) error {
+func (r *ClowdApp) ValidateUpdate(old runtime.Object) error {
 	clowdapplog.Info("validate update", "name", r.Name)
 
 	return r.processValidations(r,
 		validateDatabase,
+		validateDatabaseEngineUpdate(old),
 		validateSidecars,
 		validateInit,
 		validateDeploymentStrategy,
+		validateWorkloadKind,
+		validatePodDisruptionBudget,
+		validateMigrations,
+		validateKafkaTopics,
+		validateKafkaConnectors,
 	)
 }
 
@@ -95,6 +164,32 @@ func (r *ClowdApp) processValidations(o *ClowdApp, vfns ...appValidationFunc) er
 	)
 }
 
+// validateDatabaseEngineUpdate forbids changing the engine of a requested database, as its
+// credentials and data were made for the engine it was created with.
+func validateDatabaseEngineUpdate(old runtime.Object) appValidationFunc {
+	return func(r *ClowdApp) field.ErrorList {
+		oldApp, ok := old.(*ClowdApp)
+		if !ok || oldApp.Spec.Database.Name == "" || r.Spec.Database.Name == "" {
+			return nil
+		}
+
+		if getDatabaseEngine(oldApp) == getDatabaseEngine(r) {
+			return nil
+		}
+
+		return field.ErrorList{field.Forbidden(
+			field.NewPath("spec.Database.Engine"), "the engine of a database cannot be changed"),
+		}
+	}
+}
+
+func getDatabaseEngine(r *ClowdApp) string {
+	if r.Spec.Database.Engine == "" {
+		return "postgresql"
+	}
+	return r.Spec.Database.Engine
+}
+
 func validateDatabase(r *ClowdApp) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -110,6 +205,52 @@ func validateDatabase(r *ClowdApp) field.ErrorList {
 		)
 	}
 
+	if seed := r.Spec.Database.Seed; seed != nil {
+		if r.Spec.Database.Name == "" {
+			allErrs = append(allErrs, field.Forbidden(
+				field.NewPath("spec.Database.Seed"), "can only seed a database requested with a db name"),
+			)
+		}
+
+		sources := 0
+		if seed.ConfigMap != nil {
+			sources++
+		}
+		if seed.PVC != nil {
+			sources++
+		}
+		if seed.ObjectStore != nil {
+			sources++
+		}
+		if sources != 1 {
+			allErrs = append(allErrs, field.Invalid(
+				field.NewPath("spec.Database.Seed"), sources, "exactly one of configMap, pvc or objectStore must be set"),
+			)
+		}
+	}
+
+	if engine := r.Spec.Database.Engine; engine != "" && engine != "postgresql" {
+		enginePath := field.NewPath("spec.Database.Engine")
+		if r.Spec.Database.Version != nil {
+			allErrs = append(allErrs, field.Forbidden(enginePath, "version is only supported by the postgresql engine"))
+		}
+		if r.Spec.Database.Seed != nil {
+			allErrs = append(allErrs, field.Forbidden(enginePath, "seed is only supported by the postgresql engine"))
+		}
+		if r.Spec.Database.Pooler != nil {
+			allErrs = append(allErrs, field.Forbidden(enginePath, "pooler is only supported by the postgresql engine"))
+		}
+		if r.Spec.Database.ReadReplicas != 0 {
+			allErrs = append(allErrs, field.Forbidden(enginePath, "readReplicas is only supported by the postgresql engine"))
+		}
+		if r.Spec.Database.SharedDBAppName != "" {
+			allErrs = append(allErrs, field.Forbidden(enginePath, "the engine of a shared database is that of the app it is shared from"))
+		}
+		if r.Spec.Cyndi.Enabled {
+			allErrs = append(allErrs, field.Forbidden(enginePath, "cyndi is only supported by the postgresql engine"))
+		}
+	}
+
 	return allErrs
 }
 
@@ -136,49 +277,222 @@ func validateInit(r *ClowdApp) field.ErrorList {
 func validateSidecars(r *ClowdApp) field.ErrorList {
 	allErrs := field.ErrorList{}
 	for depIndx, deployment := range r.Spec.Deployments {
-		for carIndx, sidecar := range deployment.PodSpec.Sidecars {
-			if sidecar.Name != "token-refresher" {
-				allErrs = append(
-					allErrs,
-					field.Forbidden(
-						field.NewPath(fmt.Sprintf("spec.Deployment[%d].Sidecars[%d]", depIndx, carIndx)),
-						"Sidecar is of unknown type, must be one of [token-refresher]",
-					),
-				)
-			}
-		}
+		innerDeployment := deployment
+		containerName := r.GetDeploymentNamespacedName(&innerDeployment).Name
+		path := fmt.Sprintf("spec.Deployments[%d].PodSpec.Sidecars", depIndx)
+		allErrs = append(allErrs, validateSidecarList(path, containerName, deployment.PodSpec.Sidecars)...)
 	}
 	for jobIndx, job := range r.Spec.Jobs {
-		if job.Schedule == "" {
+		innerJob := job
+		containerName := r.GetCronJobNamespacedName(&innerJob).Name
+		path := fmt.Sprintf("spec.Jobs[%d].PodSpec.Sidecars", jobIndx)
+		allErrs = append(allErrs, validateSidecarList(path, containerName, job.PodSpec.Sidecars)...)
+	}
+	return allErrs
+}
+
+func validateSidecarList(path string, containerName string, sidecars []Sidecar) field.ErrorList {
+	allErrs := field.ErrorList{}
+	seen := map[string]bool{}
+
+	for carIndx, sidecar := range sidecars {
+		innerSidecar := sidecar
+		carPath := field.NewPath(fmt.Sprintf("%s[%d]", path, carIndx))
+
+		if seen[sidecar.Name] {
+			allErrs = append(allErrs, field.Duplicate(carPath, sidecar.Name))
+		}
+		seen[sidecar.Name] = true
+
+		if innerSidecar.IsBuiltIn() {
+			if sidecar.Image != "" {
+				allErrs = append(allErrs, field.Forbidden(
+					carPath, fmt.Sprintf("image cannot be set for built-in sidecar %s", sidecar.Name),
+				))
+			}
 			continue
 		}
-		for carIndx, sidecar := range job.PodSpec.Sidecars {
-			if sidecar.Name != "token-refresher" {
+
+		if sidecar.Image == "" {
+			allErrs = append(allErrs, field.Forbidden(
+				carPath,
+				fmt.Sprintf("Sidecar is of unknown type, must be one of %v or define an image", BuiltInSidecars),
+			))
+		}
+
+		if sidecar.Name == containerName {
+			allErrs = append(allErrs, field.Forbidden(
+				carPath, fmt.Sprintf("sidecar name %s collides with the primary container name", sidecar.Name),
+			))
+		}
+
+		for _, reserved := range ReservedContainerNames {
+			if sidecar.Name == reserved {
+				allErrs = append(allErrs, field.Forbidden(
+					carPath, fmt.Sprintf("sidecar name %s is reserved for a Clowder managed container", sidecar.Name),
+				))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func validateDeploymentStrategy(r *ClowdApp) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for depIndex, deployment := range r.Spec.Deployments {
+		if deployment.DeploymentStrategy != nil && deployment.WebServices.Public.Enabled && deployment.DeploymentStrategy.PrivateStrategy == apps.RecreateDeploymentStrategyType {
+			allErrs = append(
+				allErrs,
+				field.Forbidden(
+					field.NewPath(fmt.Sprintf("spec.Deployment[%d]", depIndex)),
+					"privateStrategy cannot be set to recreate for public web enabled deployments",
+				),
+			)
+		}
+	}
+	return allErrs
+}
+
+func validateWorkloadKind(r *ClowdApp) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for depIndex, deployment := range r.Spec.Deployments {
+		if deployment.IsStatefulSet() {
+			if deployment.DeploymentStrategy != nil {
 				allErrs = append(
 					allErrs,
 					field.Forbidden(
-						field.NewPath(fmt.Sprintf("spec.Deployment[%d].Sidecars[%d]", jobIndx, carIndx)),
-						"Sidecar is of unknown type, must be one of [token-refresher]",
+						field.NewPath(fmt.Sprintf("spec.Deployment[%d]", depIndex), "deploymentStrategy"),
+						"deploymentStrategy cannot be set when workloadKind is StatefulSet",
 					),
 				)
 			}
+			continue
+		}
+		if deployment.StatefulSet != nil {
+			allErrs = append(
+				allErrs,
+				field.Forbidden(
+					field.NewPath(fmt.Sprintf("spec.Deployment[%d]", depIndex), "statefulSet"),
+					"statefulSet options can only be set when workloadKind is StatefulSet",
+				),
+			)
 		}
 	}
 	return allErrs
 }
 
-func validateDeploymentStrategy(r *ClowdApp) field.ErrorList {
+func validatePodDisruptionBudget(r *ClowdApp) field.ErrorList {
 	allErrs := field.ErrorList{}
 	for depIndex, deployment := range r.Spec.Deployments {
-		if deployment.DeploymentStrategy != nil && deployment.WebServices.Public.Enabled && deployment.DeploymentStrategy.PrivateStrategy == apps.RecreateDeploymentStrategyType {
+		pdb := deployment.PodDisruptionBudget
+		if pdb == nil {
+			continue
+		}
+		if pdb.MaxUnavailable != nil && pdb.MinAvailable != nil {
 			allErrs = append(
 				allErrs,
 				field.Forbidden(
-					field.NewPath(fmt.Sprintf("spec.Deployment[%d]", depIndex)),
-					"privateStrategy cannot be set to recreate for public web enabled deployments",
+					field.NewPath(fmt.Sprintf("spec.Deployment[%d]", depIndex), "podDisruptionBudget", "minAvailable"),
+					"minAvailable cannot be set together with maxUnavailable",
 				),
 			)
 		}
 	}
 	return allErrs
 }
+
+func validateMigrations(r *ClowdApp) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if r.Spec.Migrations == nil {
+		return allErrs
+	}
+
+	for _, job := range r.Spec.Jobs {
+		if job.Name != r.Spec.Migrations.JobName {
+			continue
+		}
+		if job.Schedule != "" {
+			allErrs = append(
+				allErrs,
+				field.Forbidden(
+					field.NewPath("spec.Migrations", "jobName"),
+					"the migrations job cannot have a schedule",
+				),
+			)
+		}
+		return allErrs
+	}
+
+	allErrs = append(
+		allErrs,
+		field.NotFound(field.NewPath("spec.Migrations", "jobName"), r.Spec.Migrations.JobName),
+	)
+	return allErrs
+}
+
+func validateKafkaTopics(r *ClowdApp) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	declared := map[string]bool{}
+	for _, topic := range r.Spec.KafkaTopics {
+		declared[topic.TopicName] = true
+	}
+
+	for topicIndex, topic := range r.Spec.KafkaTopics {
+		topicPath := fmt.Sprintf("spec.KafkaTopics[%d]", topicIndex)
+
+		if topic.Role == "producer" && topic.ConsumerGroup != "" {
+			allErrs = append(
+				allErrs,
+				field.Forbidden(
+					field.NewPath(topicPath, "consumerGroup"),
+					"consumerGroup cannot be set when the role is producer",
+				),
+			)
+		}
+
+		// The dead letter and retry topics must not clash with each other or the declared topics
+		companionNames := map[string]bool{}
+		for _, companion := range []struct {
+			field         string
+			spec          *KafkaCompanionTopicSpec
+			defaultSuffix string
+		}{
+			{"deadLetter", topic.DeadLetter, ".dlq"},
+			{"retry", topic.Retry, ".retry"},
+		} {
+			if companion.spec == nil {
+				continue
+			}
+			suffix := companion.spec.Suffix
+			if suffix == "" {
+				suffix = companion.defaultSuffix
+			}
+			name := topic.TopicName + suffix
+			if len(name) > maxTopicNameLength {
+				allErrs = append(allErrs, field.TooLong(field.NewPath(topicPath, companion.field, "suffix"), name, maxTopicNameLength))
+			}
+			if declared[name] || companionNames[name] {
+				allErrs = append(allErrs, field.Duplicate(field.NewPath(topicPath, companion.field, "suffix"), name))
+			}
+			companionNames[name] = true
+		}
+	}
+	return allErrs
+}
+
+func validateKafkaConnectors(r *ClowdApp) field.ErrorList {
+	allErrs := field.ErrorList{}
+	seen := map[string]bool{}
+	for connectorIndex, connector := range r.Spec.KafkaConnectors {
+		if seen[connector.Name] {
+			allErrs = append(allErrs, field.Duplicate(
+				field.NewPath(fmt.Sprintf("spec.KafkaConnectors[%d]", connectorIndex), "name"), connector.Name),
+			)
+		}
+		seen[connector.Name] = true
+	}
+	return allErrs
+}
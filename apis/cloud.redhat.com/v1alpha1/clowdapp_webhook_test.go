@@ -0,0 +1,81 @@
+package v1alpha1
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apps "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestDefaultStatefulSetStrategy(t *testing.T) {
+	app := &ClowdApp{
+		Spec: ClowdAppSpec{
+			Deployments: []Deployment{{Name: "processor"}},
+		},
+	}
+
+	app.Default()
+	assert.Equal(t, apps.RollingUpdateDeploymentStrategyType, app.Spec.Deployments[0].DeploymentStrategy.PrivateStrategy)
+	assert.NoError(t, app.ValidateCreate())
+
+	app.Spec.Deployments[0].WorkloadKind = WorkloadKindStatefulSet
+	app.Default()
+	assert.Nil(t, app.Spec.Deployments[0].DeploymentStrategy)
+	assert.NoError(t, app.ValidateUpdate(app))
+}
+
+func TestDefaultDatabaseEngineVersion(t *testing.T) {
+	app := &ClowdApp{
+		Spec: ClowdAppSpec{
+			Database: DatabaseSpec{Name: "inventory", Engine: "mysql"},
+		},
+	}
+
+	app.Default()
+	assert.Nil(t, app.Spec.Database.Version)
+	assert.NoError(t, app.ValidateCreate())
+
+	app.Spec.Database.Engine = ""
+	app.Default()
+	assert.Equal(t, DefaultDatabaseVersion, *app.Spec.Database.Version)
+	assert.NoError(t, app.ValidateCreate())
+}
+
+func TestValidateCompanionTopicNameLength(t *testing.T) {
+	app := &ClowdApp{
+		Spec: ClowdAppSpec{
+			KafkaTopics: []KafkaTopicSpec{{
+				TopicName:  strings.Repeat("a", 245),
+				DeadLetter: &KafkaCompanionTopicSpec{},
+			}},
+		},
+	}
+	assert.Empty(t, validateKafkaTopics(app))
+
+	app.Spec.KafkaTopics[0].Retry = &KafkaCompanionTopicSpec{}
+	errs := validateKafkaTopics(app)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, field.ErrorTypeTooLong, errs[0].Type)
+	assert.Equal(t, "spec.KafkaTopics[0].retry.suffix", errs[0].Field)
+}
+
+func TestValidateDatabaseEngineUpdate(t *testing.T) {
+	old := &ClowdApp{
+		Spec: ClowdAppSpec{
+			Database: DatabaseSpec{Name: "inventory"},
+		},
+	}
+
+	app := old.DeepCopy()
+	app.Spec.Database.Engine = "postgresql"
+	assert.NoError(t, app.ValidateUpdate(old))
+
+	app.Spec.Database.Engine = "mysql"
+	assert.Error(t, app.ValidateUpdate(old))
+
+	// An app which did not request a database yet can pick any engine
+	old.Spec.Database.Name = ""
+	assert.NoError(t, app.ValidateUpdate(old))
+}
@@ -27,6 +27,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -166,6 +167,31 @@ type KafkaClusterConfig struct {
 	Resources strimzi.KafkaSpecKafkaResources `json:"resources,omitempty"`
 }
 
+// KafkaNamedClusterConfig defines a Kafka cluster of the environment besides the
+// default one, which topics are placed on by naming it in their cluster.
+type KafkaNamedClusterConfig struct {
+	// The name topics use to refer to this cluster.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=63
+	// +kubebuilder:validation:Pattern:="^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+	Name string `json:"name"`
+
+	// The mode of operation of the Clowder Kafka Provider for this cluster, one
+	// of (*_operator_*), (*_app-interface_*) or (*_managed_*).
+	Mode KafkaMode `json:"mode"`
+
+	// Defines options related to this Kafka cluster. If the name is unset, the
+	// cluster is named after the default cluster and the name of this one.
+	Cluster KafkaClusterConfig `json:"cluster,omitempty"`
+
+	// Defines the secret reference for this cluster. Only used in (*_managed_*)
+	// mode.
+	ManagedSecretRef NamespacedName `json:"managedSecretRef,omitempty"`
+
+	// Managed topic prefix for this cluster. Only used in (*_managed_*) mode.
+	ManagedPrefix string `json:"managedPrefix,omitempty"`
+}
+
 // KafkaConnectClusterConfig defines options related to the Kafka Connect cluster managed/monitored by Clowder
 type KafkaConnectClusterConfig struct {
 	// Defines the kafka connect cluster name (default: <kafka cluster's name>)
@@ -188,6 +214,33 @@ type KafkaConnectClusterConfig struct {
 	Resources strimzi.KafkaConnectSpecResources `json:"resources,omitempty"`
 }
 
+// TopicDeletionPolicy details what happens to a KafkaTopic once no app in the
+// environment declares it anymore
+// +kubebuilder:validation:Enum=retain;delete;delete-after-grace-period
+type TopicDeletionPolicy string
+
+// SchemaRegistryMode details the mode of operation of the schema registry
+// +kubebuilder:validation:Enum=local;app-interface;none
+type SchemaRegistryMode string
+
+// SchemaRegistryConfig configures the schema registry of the Kafka provider.
+type SchemaRegistryConfig struct {
+	// The mode of operation of the schema registry. Valid options are:
+	// (*_local_*) where a Confluent compatible schema registry is deployed next to the
+	// Kafka cluster, only available in (*_operator_*) mode, (*_app-interface_*) where
+	// the URL and credentials of the registry are read from a secret, and (*_none_*)
+	// where no schema registry is given to apps.
+	Mode SchemaRegistryMode `json:"mode,omitempty"`
+
+	// Image. If unset, default is 'docker.io/confluentinc/cp-schema-registry:7.4.0'.
+	// Only used in (*_local_*) mode.
+	Image string `json:"image,omitempty"`
+
+	// Defines the secret holding the url, and optionally the username and password,
+	// of the schema registry. Only used in (*_app-interface_*) mode.
+	SecretRef NamespacedName `json:"secretRef,omitempty"`
+}
+
 // NamespacedName type to represent a real Namespaced Name
 type NamespacedName struct {
 	// Name defines the Name of a resource.
@@ -228,6 +281,32 @@ type KafkaConfig struct {
 	// Managed topic prefix for the managed cluster. Only used in (*_managed_*) mode.
 	ManagedPrefix string `json:"managedPrefix,omitempty"`
 
+	// Defines the schema registry given to apps using Kafka in this environment.
+	SchemaRegistry SchemaRegistryConfig `json:"schemaRegistry,omitempty"`
+
+	// The policy for the KafkaTopics which no app in the environment declares
+	// anymore, for the topics which do not set their own. Valid options are
+	// (*_retain_*) which keeps the topic until the environment is removed,
+	// (*_delete_*) which deletes it right away, and (*_delete-after-grace-period_*)
+	// which deletes it once it has not been declared for the grace period. If
+	// unset, default is 'retain'. Only used in (*_operator_*) mode.
+	TopicDeletionPolicy TopicDeletionPolicy `json:"topicDeletionPolicy,omitempty"`
+
+	// How long a KafkaTopic is kept once no app declares it when the deletion
+	// policy is (*_delete-after-grace-period_*). If unset, default is '24h'.
+	TopicDeletionGracePeriod *metav1.Duration `json:"topicDeletionGracePeriod,omitempty"`
+
+	// Fails the reconciliation of a ClowdApp when an existing KafkaTopic does
+	// not match the partitions, replicas or config the app requests for it,
+	// instead of only reporting a warning. Only used in (*_app-interface_*)
+	// mode.
+	StrictTopicValidation bool `json:"strictTopicValidation,omitempty"`
+
+	// Defines the Kafka clusters of the environment besides the default one
+	// configured above, which topics can be placed on by name.
+	// +optional
+	Clusters []KafkaNamedClusterConfig `json:"clusters,omitempty"`
+
 	// (Deprecated) Defines the cluster name to be used by the Kafka Provider this will
 	// be used in some modes to locate the Kafka instance.
 	ClusterName string `json:"clusterName,omitempty"`
@@ -268,6 +347,55 @@ type DatabaseConfig struct {
 	// If using the (*_local_*) mode and PVC is set to true, this instructs the local
 	// Database instance to use a PVC instead of emptyDir for its volumes.
 	PVC bool `json:"pvc,omitempty"`
+
+	// Configures scheduled backups of every database instance created by Clowder.
+	// Only used in (*_local_*) and (*_shared_*) modes.
+	Backup *DatabaseBackupConfig `json:"backup,omitempty"`
+
+	// Puts a PgBouncer connection pooler in front of the database of every
+	// ClowdApp. Settings given in the pooler of a ClowdApp take precedence. In
+	// (*_app-interface_*) mode apps without a pooler secret use their database
+	// directly.
+	Pooler *DatabasePooler `json:"pooler,omitempty"`
+}
+
+// DatabaseBackupConfig configures scheduled backups of the database instances
+// created by the Clowder Database Provider.
+type DatabaseBackupConfig struct {
+	// The schedule of the backups in cron format.
+	Schedule string `json:"schedule"`
+
+	// The number of backups to keep for each database instance, defaults to 7.
+	// +kubebuilder:validation:Minimum=1
+	Retention int32 `json:"retention,omitempty"`
+
+	// Defines where the backups are stored, exactly one target must be set.
+	Target DatabaseBackupTarget `json:"target"`
+}
+
+// DatabaseBackupTarget defines where database backups are stored.
+type DatabaseBackupTarget struct {
+	// Stores the backups in a bucket of the MinIO object store of the
+	// ClowdEnvironment, which must be in (*_minio_*) mode.
+	ObjectStore *DatabaseBackupObjectStore `json:"objectStore,omitempty"`
+
+	// Stores the backups on a PersistentVolumeClaim created next to each
+	// database instance.
+	PVC *DatabaseBackupPVC `json:"pvc,omitempty"`
+}
+
+// DatabaseBackupObjectStore defines the bucket database backups are stored in.
+type DatabaseBackupObjectStore struct {
+	// The name of the bucket, which is created if it does not exist.
+	Bucket string `json:"bucket"`
+}
+
+// DatabaseBackupPVC defines the PersistentVolumeClaims database backups are
+// stored on.
+type DatabaseBackupPVC struct {
+	// T-shirt size of the volume holding the backups, one of small, medium, large
+	// +kubebuilder:validation:Enum={"small", "medium", "large"}
+	VolumeSize string `json:"volumeSize,omitempty"`
 }
 
 // LoggingMode details the mode of operation of the Clowder Logging Provider
@@ -463,6 +591,36 @@ type Sidecars struct {
 
 type DeploymentConfig struct {
 	OmitPullPolicy bool `json:"omitPullPolicy,omitempty"`
+
+	// TopologySpreadConstraints are applied to the deployments of every ClowdApp which do not
+	// define their own, for example to spread HA services across zones. A constraint without
+	// a labelSelector selects the pods of each deployment.
+	TopologySpreadConstraints []core.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+}
+
+// PodDisruptionBudgetConfig configures the Clowder provider controlling the creation of
+// PodDisruptionBudgets for ClowdApp deployments.
+type PodDisruptionBudgetConfig struct {
+	// Enables the creation of a PodDisruptionBudget for each ClowdApp deployment which can
+	// run more than one replica, including deployments scaled by an autoscaler.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// MaxUnavailable is the number or percentage of pods which can be unavailable during a
+	// disruption, defaults to 1.
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// NetworkPolicyConfig configures the Clowder provider controlling the creation of
+// NetworkPolicies which isolate ClowdApps from each other.
+type NetworkPolicyConfig struct {
+	// Enables the creation of a NetworkPolicy for each ClowdApp deployment, which only
+	// allows ingress from the ClowdApps declaring it as a dependency, from the same
+	// ClowdApp and from the infrastructure of the environment.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// A list of additional namespaces from which ingress is always allowed, for example
+	// the namespace of an ingress controller which is not managed by Clowder.
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
 }
 
 // ProvidersConfig defines a group of providers configuration for a ClowdEnvironment.
@@ -508,6 +666,12 @@ type ProvidersConfig struct {
 
 	// Defines the Deployment provider options
 	Deployment DeploymentConfig `json:"deployment,omitempty"`
+
+	// Defines the NetworkPolicy provider options
+	NetworkPolicy NetworkPolicyConfig `json:"networkPolicy,omitempty"`
+
+	// Defines the PodDisruptionBudget provider options
+	PodDisruptionBudget PodDisruptionBudgetConfig `json:"podDisruptionBudget,omitempty"`
 }
 
 // MinioStatus defines the status of a minio instance in local mode.
@@ -526,14 +690,15 @@ type MinioStatus struct {
 type ClowdEnvironmentStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
-	Conditions      []clusterv1.Condition `json:"conditions,omitempty"`
-	TargetNamespace string                `json:"targetNamespace,omitempty"`
-	Ready           bool                  `json:"ready,omitempty"`
-	Deployments     EnvResourceStatus     `json:"deployments,omitempty"`
-	Apps            []AppInfo             `json:"apps,omitempty"`
-	Generation      int64                 `json:"generation,omitempty"`
-	Hostname        string                `json:"hostname,omitempty"`
-	Prometheus      PrometheusStatus      `json:"prometheus,omitempty"`
+	Conditions      []clusterv1.Condition     `json:"conditions,omitempty"`
+	TargetNamespace string                    `json:"targetNamespace,omitempty"`
+	Ready           bool                      `json:"ready,omitempty"`
+	Deployments     EnvResourceStatus         `json:"deployments,omitempty"`
+	Apps            []AppInfo                 `json:"apps,omitempty"`
+	Topics          map[string]TopicOwnership `json:"topics,omitempty"`
+	Generation      int64                     `json:"generation,omitempty"`
+	Hostname        string                    `json:"hostname,omitempty"`
+	Prometheus      PrometheusStatus          `json:"prometheus,omitempty"`
 }
 
 type EnvResourceStatus struct {
@@ -548,6 +713,12 @@ type PrometheusStatus struct {
 	Hostname string `json:"hostname"`
 }
 
+// TopicOwnership lists the apps producing to and consuming from a topic.
+type TopicOwnership struct {
+	Producers []string `json:"producers,omitempty"`
+	Consumers []string `json:"consumers,omitempty"`
+}
+
 // AppInfo details information about a specific app.
 type AppInfo struct {
 	Name        string           `json:"name"`
@@ -564,6 +735,7 @@ type DeploymentInfo struct {
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster,shortName=env
+// +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="Ready",type="integer",JSONPath=".status.deployments.readyDeployments"
 // +kubebuilder:printcolumn:name="Managed",type="integer",JSONPath=".status.deployments.managedDeployments"
 // +kubebuilder:printcolumn:name="Namespace",type="string",JSONPath=".status.targetNamespace"
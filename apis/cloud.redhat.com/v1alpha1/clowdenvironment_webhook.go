@@ -0,0 +1,343 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+const (
+	// DefaultWebPort is the port web services are served on if none is given
+	DefaultWebPort int32 = 8000
+	// DefaultPrivatePort is the port private web services are served on if none is given
+	DefaultPrivatePort int32 = 10000
+	// DefaultMetricsPort is the port metrics are served on if none is given
+	DefaultMetricsPort int32 = 9000
+	// DefaultMetricsPath is the path metrics are served on if none is given
+	DefaultMetricsPath = "/metrics"
+)
+
+// log is for logging in this package.
+var clowdenvironmentlog = logf.Log.WithName("clowdenvironment-resource")
+
+func (r *ClowdEnvironment) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-cloud-redhat-com-v1alpha1-clowdenvironment,mutating=true,failurePolicy=fail,sideEffects=None,groups=cloud.redhat.com,resources=clowdenvironments,verbs=create;update,versions=v1alpha1,name=mclowdenvironment.kb.io,admissionReviewVersions={v1}
+
+var _ webhook.Defaulter = &ClowdEnvironment{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type
+func (r *ClowdEnvironment) Default() {
+	clowdenvironmentlog.Info("default", "name", r.Name)
+
+	web := &r.Spec.Providers.Web
+	if web.Port == 0 {
+		web.Port = DefaultWebPort
+	}
+	if web.PrivatePort == 0 {
+		web.PrivatePort = DefaultPrivatePort
+	}
+
+	metrics := &r.Spec.Providers.Metrics
+	if metrics.Port == 0 {
+		metrics.Port = DefaultMetricsPort
+	}
+	if metrics.Path == "" {
+		metrics.Path = DefaultMetricsPath
+	}
+
+	// New environments name their Kafka cluster after themselves. Environments created before
+	// the default existed keep the name the operator mode provider gave their running cluster,
+	// which is made from the UID.
+	kafka := &r.Spec.Providers.Kafka
+	if kafka.Mode == "operator" && kafka.Cluster.Name == "" && kafka.ClusterName == "" {
+		if r.UID == "" {
+			kafka.Cluster.Name = r.Name
+		} else {
+			kafka.Cluster.Name = fmt.Sprintf("%s-%s", r.Name, strings.Split(string(r.UID), "-")[0])
+		}
+	}
+}
+
+//+kubebuilder:webhook:path=/validate-cloud-redhat-com-v1alpha1-clowdenvironment,mutating=false,failurePolicy=fail,sideEffects=None,groups=cloud.redhat.com,resources=clowdenvironments,verbs=create;update,versions=v1alpha1,name=vclowdenvironment.kb.io,admissionReviewVersions={v1}
+
+var _ webhook.Validator = &ClowdEnvironment{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *ClowdEnvironment) ValidateCreate() error {
+	clowdenvironmentlog.Info("validate create", "name", r.Name)
+
+	return r.processValidations(r,
+		validateEnvWeb,
+		validateEnvPorts,
+		validateEnvKafka,
+		validateEnvDatabase,
+	)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *ClowdEnvironment) ValidateUpdate(_ runtime.Object) error {
+	clowdenvironmentlog.Info("validate update", "name", r.Name)
+
+	return r.processValidations(r,
+		validateEnvWeb,
+		validateEnvPorts,
+		validateEnvKafka,
+		validateEnvDatabase,
+	)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *ClowdEnvironment) ValidateDelete() error {
+	clowdenvironmentlog.Info("validate delete", "name", r.Name)
+	return nil
+}
+
+type envValidationFunc func(*ClowdEnvironment) field.ErrorList
+
+func (r *ClowdEnvironment) processValidations(o *ClowdEnvironment, vfns ...envValidationFunc) error {
+	var allErrs field.ErrorList
+
+	for _, validation := range vfns {
+		fieldList := validation(o)
+		if fieldList != nil {
+			allErrs = append(allErrs, fieldList...)
+		}
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(
+		schema.GroupKind{Group: "cloud.redhat.com", Kind: "ClowdEnvironment"},
+		r.Name, allErrs,
+	)
+}
+
+func validateEnvWeb(r *ClowdEnvironment) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if r.Spec.Providers.Web.Mode == "local" && r.Spec.Providers.Web.IngressClass == "" {
+		allErrs = append(allErrs, field.Required(
+			field.NewPath("spec", "providers", "web", "ingressClass"), "ingressClass must be set when web mode is local"),
+		)
+	}
+
+	return allErrs
+}
+
+func validateEnvPorts(r *ClowdEnvironment) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	webPath := field.NewPath("spec", "providers", "web")
+	web := r.Spec.Providers.Web
+
+	privatePort := web.PrivatePort
+	if privatePort == 0 {
+		privatePort = DefaultPrivatePort
+	}
+
+	type namedPort struct {
+		path *field.Path
+		port int32
+	}
+
+	ports := []namedPort{
+		{webPath.Child("port"), web.Port},
+		{webPath.Child("privatePort"), privatePort},
+	}
+
+	if r.Spec.Providers.Metrics.Mode != "none" && r.Spec.Providers.Metrics.Mode != "" {
+		ports = append(ports, namedPort{field.NewPath("spec", "providers", "metrics", "port"), r.Spec.Providers.Metrics.Port})
+	}
+
+	if web.Mode == "local" && web.AuthPort != 0 {
+		ports = append(ports, namedPort{webPath.Child("aiuthPort"), web.AuthPort})
+	}
+
+	if web.TLS.Enabled {
+		tlsPath := webPath.Child("tls")
+		if web.TLS.Port == 0 {
+			allErrs = append(allErrs, field.Required(tlsPath.Child("port"), "port must be set when tls is enabled"))
+		}
+		if web.TLS.PrivatePort == 0 {
+			allErrs = append(allErrs, field.Required(tlsPath.Child("privatePort"), "privatePort must be set when tls is enabled"))
+		}
+		ports = append(ports,
+			namedPort{tlsPath.Child("port"), web.TLS.Port},
+			namedPort{tlsPath.Child("privatePort"), web.TLS.PrivatePort},
+		)
+	}
+
+	seen := map[int32]*field.Path{}
+	for _, p := range ports {
+		if p.port == 0 {
+			continue
+		}
+		if other, ok := seen[p.port]; ok {
+			allErrs = append(allErrs, field.Invalid(
+				p.path, p.port, fmt.Sprintf("port collides with %s", other.String())),
+			)
+			continue
+		}
+		seen[p.port] = p.path
+	}
+
+	return allErrs
+}
+
+func validateEnvKafka(r *ClowdEnvironment) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	kafka := r.Spec.Providers.Kafka
+	if kafka.Mode == "managed" {
+		refPath := field.NewPath("spec", "providers", "kafka", "managedSecretRef")
+		if kafka.ManagedSecretRef.Name == "" {
+			allErrs = append(allErrs, field.Required(refPath.Child("name"), "managedSecretRef must be set when kafka mode is managed"))
+		}
+		if kafka.ManagedSecretRef.Namespace == "" {
+			allErrs = append(allErrs, field.Required(refPath.Child("namespace"), "managedSecretRef must be set when kafka mode is managed"))
+		}
+	}
+
+	registryPath := field.NewPath("spec", "providers", "kafka", "schemaRegistry")
+	switch kafka.SchemaRegistry.Mode {
+	case "local":
+		if kafka.Mode != "operator" {
+			allErrs = append(allErrs, field.Forbidden(registryPath.Child("mode"), "a local schema registry is only supported in operator mode"))
+		}
+	case "app-interface":
+		refPath := registryPath.Child("secretRef")
+		if kafka.SchemaRegistry.SecretRef.Name == "" {
+			allErrs = append(allErrs, field.Required(refPath.Child("name"), "secretRef must be set when schema registry mode is app-interface"))
+		}
+		if kafka.SchemaRegistry.SecretRef.Namespace == "" {
+			allErrs = append(allErrs, field.Required(refPath.Child("namespace"), "secretRef must be set when schema registry mode is app-interface"))
+		}
+	}
+
+	if kafka.TopicDeletionGracePeriod != nil && kafka.TopicDeletionGracePeriod.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(
+			field.NewPath("spec", "providers", "kafka", "topicDeletionGracePeriod"),
+			kafka.TopicDeletionGracePeriod.Duration.String(), "topicDeletionGracePeriod can not be negative"),
+		)
+	}
+
+	allErrs = append(allErrs, validateEnvKafkaClusters(r)...)
+
+	return allErrs
+}
+
+func validateEnvKafkaClusters(r *ClowdEnvironment) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	kafka := r.Spec.Providers.Kafka
+	names := map[string]bool{}
+
+	// Operator clusters each need a namespace of their own, as their KafkaTopics are named after
+	// the topics alone
+	namespaces := map[string]bool{}
+	if kafka.Mode == "operator" {
+		namespaces[kafka.Cluster.Namespace] = true
+	}
+
+	for i, cluster := range kafka.Clusters {
+		clusterPath := field.NewPath("spec", "providers", "kafka", "clusters").Index(i)
+
+		if names[cluster.Name] {
+			allErrs = append(allErrs, field.Duplicate(clusterPath.Child("name"), cluster.Name))
+		}
+		names[cluster.Name] = true
+
+		switch cluster.Mode {
+		case "operator":
+			namespacePath := clusterPath.Child("cluster", "namespace")
+			if cluster.Cluster.Namespace == "" {
+				allErrs = append(allErrs, field.Required(namespacePath, "namespace must be set when kafka cluster mode is operator"))
+			} else if namespaces[cluster.Cluster.Namespace] {
+				allErrs = append(allErrs, field.Invalid(namespacePath, cluster.Cluster.Namespace, "namespace is already used by another operator kafka cluster"))
+			}
+			namespaces[cluster.Cluster.Namespace] = true
+		case "managed":
+			refPath := clusterPath.Child("managedSecretRef")
+			if cluster.ManagedSecretRef.Name == "" {
+				allErrs = append(allErrs, field.Required(refPath.Child("name"), "managedSecretRef must be set when kafka cluster mode is managed"))
+			}
+			if cluster.ManagedSecretRef.Namespace == "" {
+				allErrs = append(allErrs, field.Required(refPath.Child("namespace"), "managedSecretRef must be set when kafka cluster mode is managed"))
+			}
+		case "app-interface":
+		default:
+			allErrs = append(allErrs, field.NotSupported(clusterPath.Child("mode"), cluster.Mode, []string{"operator", "app-interface", "managed"}))
+		}
+	}
+
+	return allErrs
+}
+
+func validateEnvDatabase(r *ClowdEnvironment) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	db := r.Spec.Providers.Database
+	if db.Mode == "app-interface" && db.CaBundleURL != "" {
+		u, err := url.ParseRequestURI(db.CaBundleURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			allErrs = append(allErrs, field.Invalid(
+				field.NewPath("spec", "providers", "db", "caBundleURL"), db.CaBundleURL, "caBundleURL must be a valid http or https URL"),
+			)
+		}
+	}
+
+	if db.Backup != nil {
+		backupPath := field.NewPath("spec", "providers", "db", "backup")
+		if db.Mode != "local" && db.Mode != "shared" {
+			allErrs = append(allErrs, field.Forbidden(backupPath, "backups are only supported in local and shared modes"))
+		}
+		if db.Backup.Schedule == "" {
+			allErrs = append(allErrs, field.Required(backupPath.Child("schedule"), "schedule must be set"))
+		}
+		target := db.Backup.Target
+		if (target.ObjectStore == nil) == (target.PVC == nil) {
+			allErrs = append(allErrs, field.Invalid(backupPath.Child("target"), target, "exactly one of objectStore or pvc must be set"))
+		}
+		if target.ObjectStore != nil {
+			if target.ObjectStore.Bucket == "" {
+				allErrs = append(allErrs, field.Required(backupPath.Child("target", "objectStore", "bucket"), "bucket must be set"))
+			}
+			if r.Spec.Providers.ObjectStore.Mode != "minio" {
+				allErrs = append(allErrs, field.Forbidden(backupPath.Child("target", "objectStore"), "backups to the object store require the minio object store mode"))
+			}
+		}
+	}
+
+	return allErrs
+}
@@ -0,0 +1,60 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestDefaultKafkaClusterName(t *testing.T) {
+	env := &ClowdEnvironment{
+		ObjectMeta: metav1.ObjectMeta{Name: "env"},
+		Spec: ClowdEnvironmentSpec{
+			Providers: ProvidersConfig{
+				Kafka: KafkaConfig{Mode: "operator"},
+			},
+		},
+	}
+
+	env.Default()
+	assert.Equal(t, "env", env.Spec.Providers.Kafka.Cluster.Name)
+
+	// Existing environments keep the name of their running cluster
+	existing := &ClowdEnvironment{
+		ObjectMeta: metav1.ObjectMeta{Name: "env", UID: types.UID("0a1b2c3d-4e5f-6789-abcd-ef0123456789")},
+		Spec: ClowdEnvironmentSpec{
+			Providers: ProvidersConfig{
+				Kafka: KafkaConfig{Mode: "operator"},
+			},
+		},
+	}
+	existing.Default()
+	assert.Equal(t, "env-0a1b2c3d", existing.Spec.Providers.Kafka.Cluster.Name)
+
+	other := &ClowdEnvironment{
+		ObjectMeta: metav1.ObjectMeta{Name: "env"},
+		Spec: ClowdEnvironmentSpec{
+			Providers: ProvidersConfig{
+				Kafka: KafkaConfig{Mode: "strimzi"},
+			},
+		},
+	}
+	other.Default()
+	assert.Empty(t, other.Spec.Providers.Kafka.Cluster.Name)
+}
+
+func TestValidateEnvAuthPort(t *testing.T) {
+	env := &ClowdEnvironment{
+		Spec: ClowdEnvironmentSpec{
+			Providers: ProvidersConfig{
+				Web: WebConfig{Mode: "local", Port: 8000, PrivatePort: 10000, AuthPort: 8000},
+			},
+		},
+	}
+
+	errs := validateEnvPorts(env)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "spec.providers.web.aiuthPort", errs[0].Field)
+}
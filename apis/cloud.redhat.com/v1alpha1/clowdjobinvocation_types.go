@@ -143,6 +143,7 @@ type ClowdJobInvocationStatus struct {
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:shortName=cji
+// +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="Completed",type="boolean",JSONPath=".status.completed"
 
 // ClowdJobInvocation is the Schema for the jobinvocations API
@@ -0,0 +1,13 @@
+package v1alpha1
+
+// v1alpha1 is the storage version of the cloud.redhat.com API group and acts as the conversion
+// hub, all other versions are converted to and from these types by the conversion webhook.
+
+// Hub marks this type as a conversion hub.
+func (*ClowdApp) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*ClowdEnvironment) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*ClowdJobInvocation) Hub() {}
@@ -24,7 +24,9 @@ package v1alpha1
 import (
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
@@ -51,6 +53,18 @@ func (in *AppInfo) DeepCopy() *AppInfo {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AppResourceStatus) DeepCopyInto(out *AppResourceStatus) {
 	*out = *in
+	if in.DeploymentStatuses != nil {
+		in, out := &in.DeploymentStatuses, &out.DeploymentStatuses
+		*out = make([]DeploymentStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.CronJobStatuses != nil {
+		in, out := &in.CronJobStatuses, &out.CronJobStatuses
+		*out = make([]CronJobStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppResourceStatus.
@@ -224,6 +238,11 @@ func (in *ClowdAppSpec) DeepCopyInto(out *ClowdAppSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Migrations != nil {
+		in, out := &in.Migrations, &out.Migrations
+		*out = new(MigrationsSpec)
+		**out = **in
+	}
 	if in.KafkaTopics != nil {
 		in, out := &in.KafkaTopics, &out.KafkaTopics
 		*out = make([]KafkaTopicSpec, len(*in))
@@ -231,6 +250,13 @@ func (in *ClowdAppSpec) DeepCopyInto(out *ClowdAppSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.KafkaConnectors != nil {
+		in, out := &in.KafkaConnectors, &out.KafkaConnectors
+		*out = make([]KafkaConnectorSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.Database.DeepCopyInto(&out.Database)
 	if in.ObjectStore != nil {
 		in, out := &in.ObjectStore, &out.ObjectStore
@@ -264,7 +290,7 @@ func (in *ClowdAppSpec) DeepCopy() *ClowdAppSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClowdAppStatus) DeepCopyInto(out *ClowdAppStatus) {
 	*out = *in
-	out.Deployments = in.Deployments
+	in.Deployments.DeepCopyInto(&out.Deployments)
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1beta1.Condition, len(*in))
@@ -272,6 +298,21 @@ func (in *ClowdAppStatus) DeepCopyInto(out *ClowdAppStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Migrations != nil {
+		in, out := &in.Migrations, &out.Migrations
+		*out = new(MigrationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KafkaConnectors != nil {
+		in, out := &in.KafkaConnectors, &out.KafkaConnectors
+		*out = make([]KafkaConnectorStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.KafkaTopicMismatches != nil {
+		in, out := &in.KafkaTopicMismatches, &out.KafkaTopicMismatches
+		*out = make([]KafkaTopicMismatch, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClowdAppStatus.
@@ -379,6 +420,13 @@ func (in *ClowdEnvironmentStatus) DeepCopyInto(out *ClowdEnvironmentStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Topics != nil {
+		in, out := &in.Topics, &out.Topics
+		*out = make(map[string]TopicOwnership, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 	out.Prometheus = in.Prometheus
 }
 
@@ -506,6 +554,29 @@ func (in *ClowdJobInvocationStatus) DeepCopy() *ClowdJobInvocationStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CronJobStatus) DeepCopyInto(out *CronJobStatus) {
+	*out = *in
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSuccessfulTime != nil {
+		in, out := &in.LastSuccessfulTime, &out.LastSuccessfulTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CronJobStatus.
+func (in *CronJobStatus) DeepCopy() *CronJobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CronJobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CyndiSpec) DeepCopyInto(out *CyndiSpec) {
 	*out = *in
@@ -521,9 +592,90 @@ func (in *CyndiSpec) DeepCopy() *CyndiSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseBackupConfig) DeepCopyInto(out *DatabaseBackupConfig) {
+	*out = *in
+	in.Target.DeepCopyInto(&out.Target)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseBackupConfig.
+func (in *DatabaseBackupConfig) DeepCopy() *DatabaseBackupConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseBackupConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseBackupObjectStore) DeepCopyInto(out *DatabaseBackupObjectStore) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseBackupObjectStore.
+func (in *DatabaseBackupObjectStore) DeepCopy() *DatabaseBackupObjectStore {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseBackupObjectStore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseBackupPVC) DeepCopyInto(out *DatabaseBackupPVC) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseBackupPVC.
+func (in *DatabaseBackupPVC) DeepCopy() *DatabaseBackupPVC {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseBackupPVC)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseBackupTarget) DeepCopyInto(out *DatabaseBackupTarget) {
+	*out = *in
+	if in.ObjectStore != nil {
+		in, out := &in.ObjectStore, &out.ObjectStore
+		*out = new(DatabaseBackupObjectStore)
+		**out = **in
+	}
+	if in.PVC != nil {
+		in, out := &in.PVC, &out.PVC
+		*out = new(DatabaseBackupPVC)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseBackupTarget.
+func (in *DatabaseBackupTarget) DeepCopy() *DatabaseBackupTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseBackupTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DatabaseConfig) DeepCopyInto(out *DatabaseConfig) {
 	*out = *in
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(DatabaseBackupConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Pooler != nil {
+		in, out := &in.Pooler, &out.Pooler
+		*out = new(DatabasePooler)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseConfig.
@@ -536,6 +688,96 @@ func (in *DatabaseConfig) DeepCopy() *DatabaseConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabasePooler) DeepCopyInto(out *DatabasePooler) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabasePooler.
+func (in *DatabasePooler) DeepCopy() *DatabasePooler {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabasePooler)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseSeed) DeepCopyInto(out *DatabaseSeed) {
+	*out = *in
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(DatabaseSeedConfigMap)
+		**out = **in
+	}
+	if in.PVC != nil {
+		in, out := &in.PVC, &out.PVC
+		*out = new(DatabaseSeedPVC)
+		**out = **in
+	}
+	if in.ObjectStore != nil {
+		in, out := &in.ObjectStore, &out.ObjectStore
+		*out = new(DatabaseSeedObjectStore)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseSeed.
+func (in *DatabaseSeed) DeepCopy() *DatabaseSeed {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseSeed)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseSeedConfigMap) DeepCopyInto(out *DatabaseSeedConfigMap) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseSeedConfigMap.
+func (in *DatabaseSeedConfigMap) DeepCopy() *DatabaseSeedConfigMap {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseSeedConfigMap)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseSeedObjectStore) DeepCopyInto(out *DatabaseSeedObjectStore) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseSeedObjectStore.
+func (in *DatabaseSeedObjectStore) DeepCopy() *DatabaseSeedObjectStore {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseSeedObjectStore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseSeedPVC) DeepCopyInto(out *DatabaseSeedPVC) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseSeedPVC.
+func (in *DatabaseSeedPVC) DeepCopy() *DatabaseSeedPVC {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseSeedPVC)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DatabaseSpec) DeepCopyInto(out *DatabaseSpec) {
 	*out = *in
@@ -544,6 +786,16 @@ func (in *DatabaseSpec) DeepCopyInto(out *DatabaseSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Seed != nil {
+		in, out := &in.Seed, &out.Seed
+		*out = new(DatabaseSeed)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Pooler != nil {
+		in, out := &in.Pooler, &out.Pooler
+		*out = new(DatabasePooler)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseSpec.
@@ -587,6 +839,16 @@ func (in *Deployment) DeepCopyInto(out *Deployment) {
 		**out = **in
 	}
 	in.Metadata.DeepCopyInto(&out.Metadata)
+	if in.StatefulSet != nil {
+		in, out := &in.StatefulSet, &out.StatefulSet
+		*out = new(StatefulSetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(DeploymentPodDisruptionBudget)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Deployment.
@@ -602,6 +864,13 @@ func (in *Deployment) DeepCopy() *Deployment {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeploymentConfig) DeepCopyInto(out *DeploymentConfig) {
 	*out = *in
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentConfig.
@@ -651,6 +920,51 @@ func (in *DeploymentMetadata) DeepCopy() *DeploymentMetadata {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentPodDisruptionBudget) DeepCopyInto(out *DeploymentPodDisruptionBudget) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentPodDisruptionBudget.
+func (in *DeploymentPodDisruptionBudget) DeepCopy() *DeploymentPodDisruptionBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentPodDisruptionBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentStatus) DeepCopyInto(out *DeploymentStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentStatus.
+func (in *DeploymentStatus) DeepCopy() *DeploymentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeploymentStrategy) DeepCopyInto(out *DeploymentStrategy) {
 	*out = *in
@@ -963,12 +1277,47 @@ func (in *KafkaClusterConfig) DeepCopy() *KafkaClusterConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaCompanionTopicSpec) DeepCopyInto(out *KafkaCompanionTopicSpec) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaCompanionTopicSpec.
+func (in *KafkaCompanionTopicSpec) DeepCopy() *KafkaCompanionTopicSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaCompanionTopicSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KafkaConfig) DeepCopyInto(out *KafkaConfig) {
 	*out = *in
 	in.Cluster.DeepCopyInto(&out.Cluster)
 	in.Connect.DeepCopyInto(&out.Connect)
 	out.ManagedSecretRef = in.ManagedSecretRef
+	out.SchemaRegistry = in.SchemaRegistry
+	if in.TopicDeletionGracePeriod != nil {
+		in, out := &in.TopicDeletionGracePeriod, &out.TopicDeletionGracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]KafkaNamedClusterConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaConfig.
@@ -997,6 +1346,75 @@ func (in *KafkaConnectClusterConfig) DeepCopy() *KafkaConnectClusterConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaConnectorSpec) DeepCopyInto(out *KafkaConnectorSpec) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaConnectorSpec.
+func (in *KafkaConnectorSpec) DeepCopy() *KafkaConnectorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaConnectorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaConnectorStatus) DeepCopyInto(out *KafkaConnectorStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaConnectorStatus.
+func (in *KafkaConnectorStatus) DeepCopy() *KafkaConnectorStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaConnectorStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaNamedClusterConfig) DeepCopyInto(out *KafkaNamedClusterConfig) {
+	*out = *in
+	in.Cluster.DeepCopyInto(&out.Cluster)
+	out.ManagedSecretRef = in.ManagedSecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaNamedClusterConfig.
+func (in *KafkaNamedClusterConfig) DeepCopy() *KafkaNamedClusterConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaNamedClusterConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaTopicMismatch) DeepCopyInto(out *KafkaTopicMismatch) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaTopicMismatch.
+func (in *KafkaTopicMismatch) DeepCopy() *KafkaTopicMismatch {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaTopicMismatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KafkaTopicSpec) DeepCopyInto(out *KafkaTopicSpec) {
 	*out = *in
@@ -1007,6 +1425,16 @@ func (in *KafkaTopicSpec) DeepCopyInto(out *KafkaTopicSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.DeadLetter != nil {
+		in, out := &in.DeadLetter, &out.DeadLetter
+		*out = new(KafkaCompanionTopicSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(KafkaCompanionTopicSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaTopicSpec.
@@ -1065,6 +1493,40 @@ func (in *MetricsWebService) DeepCopy() *MetricsWebService {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MigrationStatus) DeepCopyInto(out *MigrationStatus) {
+	*out = *in
+	if in.AppliedTime != nil {
+		in, out := &in.AppliedTime, &out.AppliedTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationStatus.
+func (in *MigrationStatus) DeepCopy() *MigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MigrationsSpec) DeepCopyInto(out *MigrationsSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationsSpec.
+func (in *MigrationsSpec) DeepCopy() *MigrationsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MigrationsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MinioStatus) DeepCopyInto(out *MinioStatus) {
 	*out = *in
@@ -1096,6 +1558,26 @@ func (in *NamespacedName) DeepCopy() *NamespacedName {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyConfig) DeepCopyInto(out *NetworkPolicyConfig) {
+	*out = *in
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyConfig.
+func (in *NetworkPolicyConfig) DeepCopy() *NetworkPolicyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectStoreConfig) DeepCopyInto(out *ObjectStoreConfig) {
 	*out = *in
@@ -1111,6 +1593,26 @@ func (in *ObjectStoreConfig) DeepCopy() *ObjectStoreConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodDisruptionBudgetConfig) DeepCopyInto(out *PodDisruptionBudgetConfig) {
+	*out = *in
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDisruptionBudgetConfig.
+func (in *PodDisruptionBudgetConfig) DeepCopy() *PodDisruptionBudgetConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PodDisruptionBudgetConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodSpec) DeepCopyInto(out *PodSpec) {
 	*out = *in
@@ -1167,7 +1669,35 @@ func (in *PodSpec) DeepCopyInto(out *PodSpec) {
 	if in.Sidecars != nil {
 		in, out := &in.Sidecars, &out.Sidecars
 		*out = make([]Sidecar, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
 }
 
@@ -1251,7 +1781,7 @@ func (in *PrometheusStatus) DeepCopy() *PrometheusStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProvidersConfig) DeepCopyInto(out *ProvidersConfig) {
 	*out = *in
-	out.Database = in.Database
+	in.Database.DeepCopyInto(&out.Database)
 	out.InMemoryDB = in.InMemoryDB
 	in.Kafka.DeepCopyInto(&out.Kafka)
 	out.Logging = in.Logging
@@ -1268,7 +1798,9 @@ func (in *ProvidersConfig) DeepCopyInto(out *ProvidersConfig) {
 	in.Testing.DeepCopyInto(&out.Testing)
 	out.Sidecars = in.Sidecars
 	out.AutoScaler = in.AutoScaler
-	out.Deployment = in.Deployment
+	in.Deployment.DeepCopyInto(&out.Deployment)
+	in.NetworkPolicy.DeepCopyInto(&out.NetworkPolicy)
+	in.PodDisruptionBudget.DeepCopyInto(&out.PodDisruptionBudget)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvidersConfig.
@@ -1301,6 +1833,22 @@ func (in *PublicWebService) DeepCopy() *PublicWebService {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaRegistryConfig) DeepCopyInto(out *SchemaRegistryConfig) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaRegistryConfig.
+func (in *SchemaRegistryConfig) DeepCopy() *SchemaRegistryConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaRegistryConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceConfig) DeepCopyInto(out *ServiceConfig) {
 	*out = *in
@@ -1334,6 +1882,41 @@ func (in *ServiceMeshConfig) DeepCopy() *ServiceMeshConfig {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Sidecar) DeepCopyInto(out *Sidecar) {
 	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(v1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(v1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]v1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Sidecar.
@@ -1392,6 +1975,28 @@ func (in *SimpleAutoScalerReplicas) DeepCopy() *SimpleAutoScalerReplicas {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StatefulSetSpec) DeepCopyInto(out *StatefulSetSpec) {
+	*out = *in
+	if in.VolumeClaimTemplates != nil {
+		in, out := &in.VolumeClaimTemplates, &out.VolumeClaimTemplates
+		*out = make([]v1.PersistentVolumeClaim, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatefulSetSpec.
+func (in *StatefulSetSpec) DeepCopy() *StatefulSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(StatefulSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TLS) DeepCopyInto(out *TLS) {
 	*out = *in
@@ -1453,6 +2058,31 @@ func (in *TokenRefresherConfig) DeepCopy() *TokenRefresherConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopicOwnership) DeepCopyInto(out *TopicOwnership) {
+	*out = *in
+	if in.Producers != nil {
+		in, out := &in.Producers, &out.Producers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Consumers != nil {
+		in, out := &in.Consumers, &out.Consumers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopicOwnership.
+func (in *TopicOwnership) DeepCopy() *TopicOwnership {
+	if in == nil {
+		return nil
+	}
+	out := new(TopicOwnership)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WebConfig) DeepCopyInto(out *WebConfig) {
 	*out = *in
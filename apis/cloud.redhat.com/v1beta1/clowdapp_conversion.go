@@ -0,0 +1,43 @@
+package v1beta1
+
+import (
+	"github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// ConvertTo converts this ClowdApp to the Hub version (v1alpha1).
+func (src *ClowdApp) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.ClowdApp)
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	if err := convertViaJSON(&src.Spec, &dst.Spec); err != nil {
+		return err
+	}
+
+	return convertViaJSON(&src.Status, &dst.Status)
+}
+
+// ConvertFrom converts from the Hub version (v1alpha1) to this version. Deprecated fields are
+// folded into their replacements before being dropped.
+func (dst *ClowdApp) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.ClowdApp).DeepCopy()
+
+	for i := range src.Spec.Deployments {
+		deployment := &src.Spec.Deployments[i]
+		if deployment.Replicas == nil && deployment.MinReplicas != nil {
+			deployment.Replicas = deployment.MinReplicas
+		}
+		if bool(deployment.Web) {
+			deployment.WebServices.Public.Enabled = true
+		}
+	}
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	if err := convertViaJSON(&src.Spec, &dst.Spec); err != nil {
+		return err
+	}
+
+	return convertViaJSON(&src.Status, &dst.Status)
+}
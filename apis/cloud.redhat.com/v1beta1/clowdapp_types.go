@@ -0,0 +1,921 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	keda "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	apps "k8s.io/api/apps/v1"
+	batch "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// InitContainer is a struct defining a k8s init container. This will be
+// deployed along with the parent pod and is used to carry out one time
+// initialization procedures.
+type InitContainer struct {
+	// Name gives an identifier in the situation where multiple init containers exist
+	Name string `json:"name,omitempty"`
+
+	// Image refers to the container image used to create the init container
+	// (if different from the primary pod image).
+	Image string `json:"image,omitempty"`
+
+	// A list of commands to run inside the parent Pod.
+	Command []string `json:"command,omitempty"`
+
+	// A list of args to be passed to the init container.
+	Args []string `json:"args,omitempty"`
+
+	// If true, inheirts the environment variables from the parent pod.
+	// specification
+	InheritEnv bool `json:"inheritEnv,omitempty"`
+
+	// A list of environment variables used only by the initContainer.
+	Env []v1.EnvVar `json:"env,omitempty"`
+}
+
+// DatabaseSpec is a struct defining a database to be exposed to a ClowdApp.
+type DatabaseSpec struct {
+	// Defines the Version of the PostGreSQL database, defaults to 12.
+	// +kubebuilder:validation:Enum:=10;12;13;14;15
+	Version *int32 `json:"version,omitempty"`
+
+	// Defines the Name of the database to be created. This will be used as the
+	// name of the logical database inside the database server in (*_local_*) mode
+	// and the name of the secret to be used for Database configuration in (*_app-interface_*) mode.
+	Name string `json:"name,omitempty"`
+
+	// Defines the Name of the app to share a database from
+	SharedDBAppName string `json:"sharedDbAppName,omitempty"`
+
+	// T-shirt size, one of small, medium, large
+	// +kubebuilder:validation:Enum={"small", "medium", "large"}
+	DBVolumeSize string `json:"dbVolumeSize,omitempty"`
+
+	// T-shirt size, one of small, medium, large
+	// +kubebuilder:validation:Enum={"small", "medium", "large"}
+	DBResourceSize string `json:"dbResourceSize,omitempty"`
+
+	// Defines a source of SQL which is loaded into the database once it has
+	// been created. Only used in (*_local_*) and (*_shared_*) modes.
+	Seed *DatabaseSeed `json:"seed,omitempty"`
+
+	// Gives the app the admin credentials of the database server as well as
+	// the credentials of its own role. Only used in (*_shared_*) mode, where
+	// every app otherwise only has access to its own database.
+	AdminCredentials bool `json:"adminCredentials,omitempty"`
+
+	// Puts a PgBouncer connection pooler in front of the database and points
+	// the app config at it. In (*_local_*) and (*_shared_*) modes the pooler is
+	// deployed next to the app, in (*_app-interface_*) mode it is read from a
+	// secret annotated with clowder/database-pooler.
+	Pooler *DatabasePooler `json:"pooler,omitempty"`
+
+	// The number of streaming read replicas to run next to the database, which
+	// are given to the app as read replicas. Only used in (*_local_*) mode.
+	// +kubebuilder:validation:Minimum=0
+	ReadReplicas int32 `json:"readReplicas,omitempty"`
+
+	// The database engine, one of postgresql, mysql or mongodb, defaults to
+	// postgresql. The version, seed, pooler and read replicas are only
+	// supported by postgresql, the other engines are only available in
+	// (*_local_*) and (*_app-interface_*) modes.
+	// +kubebuilder:validation:Enum={"postgresql", "mysql", "mongodb"}
+	Engine string `json:"engine,omitempty"`
+}
+
+// DatabasePooler defines the settings of a PgBouncer connection pooler.
+type DatabasePooler struct {
+	// The pool mode, one of session, transaction or statement, defaults to
+	// transaction.
+	// +kubebuilder:validation:Enum={"session", "transaction", "statement"}
+	Mode string `json:"mode,omitempty"`
+
+	// The number of server connections kept for each user and database pair,
+	// defaults to 20.
+	// +kubebuilder:validation:Minimum=1
+	PoolSize int32 `json:"poolSize,omitempty"`
+
+	// The maximum number of client connections, defaults to 100.
+	// +kubebuilder:validation:Minimum=1
+	MaxClientConnections int32 `json:"maxClientConnections,omitempty"`
+}
+
+// MigrationsSpec defines the job which migrates the database schema of a
+// ClowdApp.
+type MigrationsSpec struct {
+	// The name of a job in the jobs list of the ClowdApp, which must not have a
+	// schedule. It is run once for every change of its image.
+	JobName string `json:"jobName"`
+}
+
+// DatabaseSeed defines where the SQL used to seed a database is read from,
+// exactly one source must be set. The seed is applied once, a change to the
+// seed source applies the new seed on top of the existing data.
+type DatabaseSeed struct {
+	// A ConfigMap in the namespace of the ClowdApp holding the SQL.
+	ConfigMap *DatabaseSeedConfigMap `json:"configMap,omitempty"`
+
+	// A file on a PersistentVolumeClaim in the namespace of the ClowdApp.
+	PVC *DatabaseSeedPVC `json:"pvc,omitempty"`
+
+	// An object in a bucket of the MinIO object store of the ClowdEnvironment.
+	ObjectStore *DatabaseSeedObjectStore `json:"objectStore,omitempty"`
+}
+
+// DatabaseSeedConfigMap references a key of a ConfigMap holding SQL.
+type DatabaseSeedConfigMap struct {
+	// Name of the ConfigMap.
+	Name string `json:"name"`
+
+	// Key of the ConfigMap holding the SQL, defaults to seed.sql.
+	Key string `json:"key,omitempty"`
+}
+
+// DatabaseSeedPVC references a SQL file on a PersistentVolumeClaim.
+type DatabaseSeedPVC struct {
+	// Name of the PersistentVolumeClaim.
+	ClaimName string `json:"claimName"`
+
+	// Path of the SQL file on the volume.
+	Path string `json:"path"`
+}
+
+// DatabaseSeedObjectStore references a SQL file in an object store bucket.
+type DatabaseSeedObjectStore struct {
+	// Name of the bucket.
+	Bucket string `json:"bucket"`
+
+	// Key of the object holding the SQL.
+	Key string `json:"key"`
+}
+
+// Job defines a ClowdJob
+// A Job struct will deploy as a CronJob if `schedule` is set
+// and will deploy as a Job if it is not set. Unsupported fields
+// will be dropped from Jobs
+type Job struct {
+	// Name defines identifier of the Job. This name will be used to name the
+	// CronJob resource, the container will be name identically.
+	Name string `json:"name"`
+
+	// Disabled allows a job to be disabled, as such, the resource is not
+	// created on the system and cannot be invoked with a CJI
+	Disabled bool `json:"disabled,omitempty"`
+
+	// Defines the schedule for the job to run
+	Schedule string `json:"schedule,omitempty"`
+
+	// Defines the parallelism of the job
+	Parallelism *int32 `json:"parallelism,omitempty"`
+
+	// Defines the completions of the job
+	Completions *int32 `json:"completions,omitempty"`
+
+	// PodSpec defines a container running inside the CronJob.
+	PodSpec PodSpec `json:"podSpec"`
+
+	// Defines the restart policy for the CronJob, defaults to never
+	RestartPolicy v1.RestartPolicy `json:"restartPolicy,omitempty"`
+
+	// Defines the concurrency policy for the CronJob, defaults to Allow
+	// Only applies to Cronjobs
+	ConcurrencyPolicy batch.ConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+
+	// This flag tells the controller to suspend subsequent executions, it does
+	// not apply to already started executions.  Defaults to false.
+	// Only applies to Cronjobs
+	Suspend *bool `json:"suspend,omitempty"`
+
+	// The number of successful finished jobs to retain. Value must be non-negative integer.
+	// Defaults to 3.
+	// Only applies to Cronjobs
+	SuccessfulJobsHistoryLimit *int32 `json:"successfulJobsHistoryLimit,omitempty"`
+
+	// The number of failed finished jobs to retain. Value must be non-negative integer.
+	// Defaults to 1.
+	// Only applies to Cronjobs
+	FailedJobsHistoryLimit *int32 `json:"failedJobsHistoryLimit,omitempty"`
+
+	// Defines the StartingDeadlineSeconds for the CronJob
+	StartingDeadlineSeconds *int64 `json:"startingDeadlineSeconds,omitempty"`
+
+	// The activeDeadlineSeconds for the Job or CronJob.
+	// More info: https://kubernetes.io/docs/concepts/workloads/controllers/job/
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+}
+
+// PublicWebService is the definition of the public web service. There can be only
+// one public service managed by Clowder.
+type PublicWebService struct {
+
+	// Enabled describes if Clowder should enable the public service and provide the
+	// configuration in the cdappconfig.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// APIPath describes the api path that will be configured to serve this backend from.
+	APIPath string `json:"apiPath,omitempty"`
+
+	// WhitelistPaths define the paths that do not require authentication
+	WhitelistPaths []string `json:"whitelistPaths,omitempty"`
+}
+
+// AppProtocol is used to define an appProtocol for Istio
+// +kubebuilder:validation:Enum={"http", "http2", "https", "tcp", "tls", "grpc", "grpc-web", "mongo", "mysql", "redis"}
+type AppProtocol string
+
+// PrivateWebService is the definition of the private web service. There can be only
+// one private service managed by Clowder.
+type PrivateWebService struct {
+	// Enabled describes if Clowder should enable the private service and provide the
+	// configuration in the cdappconfig.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// AppProtocol determines the protocol to be used for the private port, (defaults to http)
+	AppProtocol AppProtocol `json:"appProtocol,omitempty"`
+}
+
+// MetricsWebService is the definition of the metrics web service. This is automatically
+// enabled and the configuration here at the moment is included for completeness, as there
+// are no configurable options.
+type MetricsWebService struct {
+}
+
+// WebServices defines the structs for the three exposed web services: public,
+// private and metrics.
+type WebServices struct {
+	Public  PublicWebService  `json:"public,omitempty"`
+	Private PrivateWebService `json:"private,omitempty"`
+	Metrics MetricsWebService `json:"metrics,omitempty"`
+}
+
+// K8sAccessLevel defines the access level for the deployment, one of 'default', 'view' or 'edit'
+// +kubebuilder:validation:Enum={"default", "view", "", "edit"}
+type K8sAccessLevel string
+
+type DeploymentMetadata struct {
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// WorkloadKind defines the kind of k8s resource a Deployment is rendered as, one of
+// 'Deployment' or 'StatefulSet'
+// +kubebuilder:validation:Enum={"Deployment", "StatefulSet", ""}
+type WorkloadKind string
+
+const (
+	// WorkloadKindDeployment renders the Deployment as an apps.Deployment (the default)
+	WorkloadKindDeployment WorkloadKind = "Deployment"
+	// WorkloadKindStatefulSet renders the Deployment as an apps.StatefulSet
+	WorkloadKindStatefulSet WorkloadKind = "StatefulSet"
+)
+
+// StatefulSetSpec defines the options that only apply when a Deployment is rendered as a
+// StatefulSet.
+type StatefulSetSpec struct {
+	// A list of PersistentVolumeClaims in standard k8s format, a PVC will be created for each
+	// replica from each template. The claims can be mounted into the pod by referencing the
+	// template name in the PodSpec volumeMounts.
+	VolumeClaimTemplates []v1.PersistentVolumeClaim `json:"volumeClaimTemplates,omitempty"`
+
+	// Controls how pods are created during initial scale up, when replacing pods on nodes, or
+	// when scaling down, defaults to OrderedReady.
+	PodManagementPolicy apps.PodManagementPolicyType `json:"podManagementPolicy,omitempty"`
+}
+
+// Deployment defines a service running inside a ClowdApp and will output a deployment resource.
+// Only one container per pod is allowed and this is defined in the PodSpec attribute.
+type Deployment struct {
+	// Name defines the identifier of a Pod inside the ClowdApp. This name will
+	// be used along side the name of the ClowdApp itself to form a <app>-<pod>
+	// pattern which will be used for all other created resources and also for
+	// some labels. It must be unique within a ClowdApp.
+	Name string `json:"name"`
+
+	// Defines the desired replica count for the pod
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	WebServices WebServices `json:"webServices,omitempty"`
+
+	// PodSpec defines a container running inside a ClowdApp.
+	PodSpec PodSpec `json:"podSpec"`
+
+	// K8sAccessLevel defines the level of access for this deployment
+	K8sAccessLevel K8sAccessLevel `json:"k8sAccessLevel,omitempty"`
+
+	// AutoScaler defines the configuration for the Keda auto scaler
+	AutoScaler *AutoScaler `json:"autoScaler,omitempty"`
+
+	AutoScalerSimple *AutoScalerSimple `json:"autoScalerSimple,omitempty"`
+
+	// DeploymentStrategy allows the deployment strategy to be set only if the
+	// deployment has no public service enabled
+	DeploymentStrategy *DeploymentStrategy `json:"deploymentStrategy,omitempty"`
+
+	Metadata DeploymentMetadata `json:"metadata,omitempty"`
+
+	// WorkloadKind selects the kind of resource the deployment is rendered as, either a
+	// Deployment or a StatefulSet, defaults to Deployment. A StatefulSet is given stable
+	// network identities through a headless service named <app>-<deployment>-headless.
+	WorkloadKind WorkloadKind `json:"workloadKind,omitempty"`
+
+	// StatefulSet defines options that are only used when WorkloadKind is set to StatefulSet.
+	StatefulSet *StatefulSetSpec `json:"statefulSet,omitempty"`
+
+	// PodDisruptionBudget overrides the PodDisruptionBudget policy of the environment for
+	// this deployment.
+	PodDisruptionBudget *DeploymentPodDisruptionBudget `json:"podDisruptionBudget,omitempty"`
+}
+
+// DeploymentPodDisruptionBudget overrides the PodDisruptionBudget created for a deployment.
+type DeploymentPodDisruptionBudget struct {
+	// Enabled overrides whether a PodDisruptionBudget is created for the deployment. If
+	// unset, the policy of the environment is used. A PodDisruptionBudget is never created
+	// for a deployment which can only run a single replica.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// MaxUnavailable is the number or percentage of pods which can be unavailable during a
+	// disruption, defaults to the value set in the environment.
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// MinAvailable is the number or percentage of pods which must remain available during a
+	// disruption. It cannot be used together with MaxUnavailable.
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+}
+
+type DeploymentStrategy struct {
+	// PrivateStrategy allows a deployment that only uses a private port to set
+	// the deployment strategy one of Recreate or Rolling, default for a
+	// private service is Recreate. This is to enable a quicker roll out for
+	// services that do not have public facing endpoints.
+	PrivateStrategy apps.DeploymentStrategyType `json:"privateStrategy,omitempty"`
+}
+
+type Sidecar struct {
+	// The name of the sidecar. Either one of the built-in sidecars, (token-refresher),
+	// or the name of a user defined sidecar container, in which case an image must
+	// also be supplied.
+	Name string `json:"name"`
+
+	// Defines if the sidecar is enabled, defaults to False
+	Enabled bool `json:"enabled"`
+
+	// Image refers to the container image used to create a user defined
+	// sidecar. Must not be set for built-in sidecars.
+	Image string `json:"image,omitempty"`
+
+	// The command that will be invoked inside the sidecar at startup.
+	Command []string `json:"command,omitempty"`
+
+	// A list of args to be passed to the sidecar container.
+	Args []string `json:"args,omitempty"`
+
+	// A list of environment variables used only by the sidecar container.
+	Env []v1.EnvVar `json:"env,omitempty"`
+
+	// A pass-through of a resource requirements in k8s ResourceRequirements
+	// format. If omitted, the default resource requirements from the
+	// ClowdEnvironment will be used.
+	Resources v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// A pass-through of a Liveness Probe specification in standard k8s format.
+	LivenessProbe *v1.Probe `json:"livenessProbe,omitempty"`
+
+	// A pass-through of a Readiness Probe specification in standard k8s format.
+	ReadinessProbe *v1.Probe `json:"readinessProbe,omitempty"`
+
+	// A pass-through of a list of VolumesMounts in standard k8s format, the
+	// volumes themselves must be defined in the parent PodSpec.
+	VolumeMounts []v1.VolumeMount `json:"volumeMounts,omitempty"`
+
+	// If true, the sidecar will have the cdappconfig mounted and the ACG_CONFIG
+	// environment variable set, in the same way as the primary container.
+	InheritConfig bool `json:"inheritConfig,omitempty"`
+}
+
+// Metadata for applying annotations etc to PodSpec
+type PodspecMetadata struct {
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// PodSpec defines a container running inside a ClowdApp.
+type PodSpec struct {
+	// Image refers to the container image used to create the pod.
+	Image string `json:"image,omitempty"`
+
+	// A list of init containers used to perform at-startup operations.
+	InitContainers []InitContainer `json:"initContainers,omitempty"`
+
+	// Allows for defining custom PodSpec metadata, such as annotations
+	Metadata PodspecMetadata `json:"metadata,omitempty"`
+
+	// The command that will be invoked inside the pod at startup.
+	Command []string `json:"command,omitempty"`
+
+	// A list of args to be passed to the pod container.
+	Args []string `json:"args,omitempty"`
+
+	// A list of environment variables in k8s defined format.
+	Env []v1.EnvVar `json:"env,omitempty"`
+
+	// A pass-through of a resource requirements in k8s ResourceRequirements
+	// format. If omitted, the default resource requirements from the
+	// ClowdEnvironment will be used.
+	Resources v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// A pass-through of a Liveness Probe specification in standard k8s format.
+	// If omitted, a standard probe will be setup point to the webPort defined
+	// in the ClowdEnvironment and a path of /healthz. Ignored if Web is set to
+	// false.
+	LivenessProbe *v1.Probe `json:"livenessProbe,omitempty"`
+
+	// A pass-through of a Readiness Probe specification in standard k8s format.
+	// If omitted, a standard probe will be setup point to the webPort defined
+	// in the ClowdEnvironment and a path of /healthz. Ignored if Web is set to
+	// false.
+	ReadinessProbe *v1.Probe `json:"readinessProbe,omitempty"`
+
+	// A pass-through of a list of Volumes in standa k8s format.
+	Volumes []v1.Volume `json:"volumes,omitempty"`
+
+	// A pass-through of a list of VolumesMounts in standa k8s format.
+	VolumeMounts []v1.VolumeMount `json:"volumeMounts,omitempty"`
+
+	// Lists the expected side cars, will be validated in the validating webhook
+	Sidecars []Sidecar `json:"sidecars,omitempty"`
+
+	// MachinePool allows the pod to be scheduled to a particular machine pool.
+	MachinePool string `json:"machinePool,omitempty"`
+
+	// Affinity defines the scheduling constraints of the pod, it replaces the default pod
+	// anti affinity which spreads replicas across zones and nodes.
+	Affinity *v1.Affinity `json:"affinity,omitempty"`
+
+	// TopologySpreadConstraints defines how the pods are spread across topology domains. A
+	// constraint without a labelSelector selects the pods of the deployment. If unset, the
+	// default constraints of the environment are used for deployments.
+	TopologySpreadConstraints []v1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// Tolerations are added to the pod, alongside the toleration for the MachinePool.
+	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
+
+	// PriorityClassName sets the priority class of the pod.
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// NodeSelector restricts the nodes the pod can be scheduled to.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+}
+
+// SimpleAutoScalerMetric defines a metric of either a value or utilization
+type SimpleAutoScalerMetric struct {
+	ScaleAtValue       string `json:"scaleAtValue,omitempty"`
+	ScaleAtUtilization int32  `json:"scaleAtUtilization,omitempty"`
+}
+
+// SimpleAutoScalerReplicas defines the minimum and maximum replica counts for the auto scaler
+type SimpleAutoScalerReplicas struct {
+	Min int32 `json:"min"`
+	Max int32 `json:"max"`
+}
+
+// SimpleAutoScaler defines a simple HPA with scaling for RAM and CPU by
+// value and utilization thresholds, along with replica count limits
+type AutoScalerSimple struct {
+	Replicas SimpleAutoScalerReplicas `json:"replicas"`
+	RAM      SimpleAutoScalerMetric   `json:"ram,omitempty"`
+	CPU      SimpleAutoScalerMetric   `json:"cpu,omitempty"`
+}
+
+// AutoScaler defines the autoscaling parameters of a KEDA ScaledObject targeting the given deployment.
+type AutoScaler struct {
+	// PollingInterval is the interval (in seconds) to check each trigger on.
+	// Default is 30 seconds.
+	// +optional
+	PollingInterval *int32 `json:"pollingInterval,omitempty"`
+	// CooldownPeriod is the interval (in seconds) to wait after the last trigger reported active before
+	// scaling the deployment down. Default is 5 minutes (300 seconds).
+	// +optional
+	CooldownPeriod *int32 `json:"cooldownPeriod,omitempty"`
+	// MaxReplicaCount is the maximum number of replicas the scaler will scale the deployment to.
+	// Default is 10.
+	// +optional
+	MaxReplicaCount *int32 `json:"maxReplicaCount,omitempty"`
+	// MinReplicaCount is the minimum number of replicas the scaler will scale the deployment to.
+	MinReplicaCount *int32 `json:"minReplicaCount,omitempty"`
+	// +optional
+	Advanced *keda.AdvancedConfig `json:"advanced,omitempty"`
+	// +optional
+	Triggers []keda.ScaleTriggers `json:"triggers,omitempty"`
+	// +optional
+	Fallback *keda.Fallback `json:"fallback,omitempty"`
+	// ExternalHPA allows replicas on deployments to be controlled by another resource, but will
+	// not be allowed to fall under the minReplicas as set in the ClowdApp.
+	ExternalHPA bool `json:"externalHPA,omitempty"`
+}
+
+// CyndiSpec is used to indicate whether a ClowdApp needs database syndication configured by the
+// cyndi operator and exposes a limited set of cyndi configuration options
+type CyndiSpec struct {
+	// Enables or Disables the Cyndi pipeline for the Clowdapp
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Application name - if empty will default to Clowdapp's name
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=64
+	// +kubebuilder:validation:Pattern:="[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*"
+	AppName string `json:"appName,omitempty"`
+
+	// Desired host syndication type (all or Insights hosts only) - defaults to false (All hosts)
+	InsightsOnly bool `json:"insightsOnly,omitempty"`
+}
+
+// KafkaTopicSpec defines the desired state of KafkaTopic
+type KafkaTopicSpec struct {
+	// we re-define this spec rather than use strimzi.KafkaTopicSpec so that a ClowdApp's topic
+	// spec has:
+	//   * partitions optional
+	//   * replicas optional
+	//   * topicName required
+
+	// A key/value pair describing the configuration of a particular topic.
+	// +optional
+	Config map[string]string `json:"config,omitempty"`
+
+	// The requested number of partitions for this topic. If unset, default is '3'
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=200000
+	Partitions int32 `json:"partitions,omitempty"`
+
+	// The requested number of replicas for this topic. If unset, default is '3'
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=32767
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// The requested name for this topic.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=249
+	// +kubebuilder:validation:Pattern:="[a-zA-Z0-9\\._\\-]"
+	TopicName string `json:"topicName"`
+
+	// Whether the app produces to, consumes from, or does both with this
+	// topic. If unset, the app is assumed to do both.
+	// +optional
+	// +kubebuilder:validation:Enum={"producer", "consumer", "both"}
+	Role string `json:"role,omitempty"`
+
+	// The consumer group the app consumes this topic with. Can not be set when
+	// the role is producer.
+	// +optional
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=249
+	ConsumerGroup string `json:"consumerGroup,omitempty"`
+
+	// The policy for this topic once no app in the environment declares it
+	// anymore, overriding the topicDeletionPolicy of the ClowdEnvironment. When
+	// the apps declaring a topic set different policies, the topic is kept the
+	// longest of them.
+	// +optional
+	DeletionPolicy TopicDeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// When set, a dead letter topic is created along with this topic, named
+	// after it with the '.dlq' suffix unless another suffix is given.
+	// +optional
+	DeadLetter *KafkaCompanionTopicSpec `json:"deadLetter,omitempty"`
+
+	// When set, a retry topic is created along with this topic, named after it
+	// with the '.retry' suffix unless another suffix is given.
+	// +optional
+	Retry *KafkaCompanionTopicSpec `json:"retry,omitempty"`
+
+	// The name of the Kafka cluster of the ClowdEnvironment this topic is
+	// placed on. If unset, the topic is placed on the default cluster. The dead
+	// letter and retry topics are placed on the same cluster.
+	// +optional
+	Cluster string `json:"cluster,omitempty"`
+}
+
+// KafkaCompanionTopicSpec defines a dead letter or retry topic created along
+// with a KafkaTopicSpec. Unset values are taken from the topic.
+type KafkaCompanionTopicSpec struct {
+	// The suffix appended to the name of the topic to name the companion topic.
+	// +optional
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=32
+	// +kubebuilder:validation:Pattern:="^[a-zA-Z0-9\\._\\-]+$"
+	Suffix string `json:"suffix,omitempty"`
+
+	// A key/value pair describing the configuration of the companion topic.
+	// +optional
+	Config map[string]string `json:"config,omitempty"`
+
+	// The requested number of partitions for the companion topic.
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=200000
+	Partitions int32 `json:"partitions,omitempty"`
+
+	// The requested number of replicas for the companion topic.
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=32767
+	Replicas int32 `json:"replicas,omitempty"`
+}
+
+// KafkaConnectorSpec defines a Kafka Connect connector run for the ClowdApp
+type KafkaConnectorSpec struct {
+	// The name of the connector, unique within the ClowdApp.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=63
+	// +kubebuilder:validation:Pattern:="^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+	Name string `json:"name"`
+
+	// The Java class of the connector.
+	// +kubebuilder:validation:MinLength:=1
+	Class string `json:"class"`
+
+	// The maximum number of tasks the connector runs. If unset, the default of
+	// the connector is used.
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+	TasksMax int32 `json:"tasksMax,omitempty"`
+
+	// A key/value pair describing the configuration of the connector. Values
+	// are Go templates which can refer to the database of the app, as in
+	// '{{ .Database.Hostname }}', and to the names of its topics, as in
+	// '{{ topic "requested-name" }}'.
+	// +optional
+	Config map[string]string `json:"config,omitempty"`
+}
+
+type TestingSpec struct {
+	IqePlugin string `json:"iqePlugin"`
+}
+
+// ClowdAppSpec is the main specification for a single Clowder Application
+// it defines n pods along with dependencies that are shared between them.
+type ClowdAppSpec struct {
+	// A list of deployments
+	Deployments []Deployment `json:"deployments,omitempty"`
+
+	// A list of jobs
+	Jobs []Job `json:"jobs,omitempty"`
+
+	// Defines a job which migrates the database schema of the app, the
+	// deployments are only updated once it has succeeded for their image.
+	Migrations *MigrationsSpec `json:"migrations,omitempty"`
+
+	// The name of the ClowdEnvironment resource that this ClowdApp will use as
+	// its base. This does not mean that the ClowdApp needs to be placed in the
+	// same directory as the targetNamespace of the ClowdEnvironment.
+	EnvName string `json:"envName"`
+
+	// A list of Kafka topics that will be created and made available to all
+	// the pods listed in the ClowdApp.
+	KafkaTopics []KafkaTopicSpec `json:"kafkaTopics,omitempty"`
+
+	// A list of Kafka Connect connectors run for the app. When the app's
+	// ClowdEnvironment has the kafka provider set to (*_operator_*) mode,
+	// Clowder will create a KafkaConnector for each of them in the
+	// environment's kafka-connect namespace.
+	KafkaConnectors []KafkaConnectorSpec `json:"kafkaConnectors,omitempty"`
+
+	// The database specification defines a single database, the configuration
+	// of which will be made available to all the pods in the ClowdApp.
+	Database DatabaseSpec `json:"database,omitempty"`
+
+	// A list of string names defining storage buckets. In certain modes,
+	// defined by the ClowdEnvironment, Clowder will create those buckets.
+	ObjectStore []string `json:"objectStore,omitempty"`
+
+	// If inMemoryDb is set to true, Clowder will pass configuration
+	// of an In Memory Database to the pods in the ClowdApp. This single
+	// instance will be shared between all apps.
+	InMemoryDB bool `json:"inMemoryDb,omitempty"`
+
+	// If featureFlags is set to true, Clowder will pass configuration of a
+	// FeatureFlags instance to the pods in the ClowdApp. This single
+	// instance will be shared between all apps.
+	FeatureFlags bool `json:"featureFlags,omitempty"`
+
+	// A list of dependencies in the form of the name of the ClowdApps that are
+	// required to be present for this ClowdApp to function.
+	Dependencies []string `json:"dependencies,omitempty"`
+
+	// A list of optional dependencies in the form of the name of the ClowdApps that are
+	// will be added to the configuration when present.
+	OptionalDependencies []string `json:"optionalDependencies,omitempty"`
+
+	// Iqe plugin and other specifics
+	Testing TestingSpec `json:"testing,omitempty"`
+
+	// Configures 'cyndi' database syndication for this app. When the app's ClowdEnvironment has
+	// the kafka provider set to (*_operator_*) mode, Clowder will configure a CyndiPipeline
+	// for this app in the environment's kafka-connect namespace. When the kafka provider is in
+	// (*_app-interface_*) mode, Clowder will check to ensure that a CyndiPipeline resource exists
+	// for the application in the environment's kafka-connect namespace. For all other kafka
+	// provider modes, this configuration option has no effect.
+	Cyndi CyndiSpec `json:"cyndi,omitempty"`
+
+	// Disabled turns off reconciliation for this ClowdApp
+	Disabled bool `json:"disabled,omitempty"`
+}
+
+const (
+	// Ready means all the deployments are ready
+	DeploymentsReady clusterv1.ConditionType = "DeploymentsReady"
+	// ReconciliationSuccessful represents status of successful reconciliation
+	ReconciliationSuccessful clusterv1.ConditionType = "ReconciliationSuccessful"
+	// ReconciliationFailed means the reconciliation failed
+	ReconciliationFailed clusterv1.ConditionType = "ReconciliationFailed"
+	// JobInvocationComplete means all the Jobs have finished
+	JobInvocationComplete clusterv1.ConditionType = "JobInvocationComplete"
+	// DatabaseSeeded means the database of the app has been seeded
+	DatabaseSeeded clusterv1.ConditionType = "DatabaseSeeded"
+	// MigrationsApplied means the migrations have been applied for the current image
+	MigrationsApplied clusterv1.ConditionType = "MigrationsApplied"
+	// KafkaTopicsMatch means the existing KafkaTopics match the spec the app requests
+	KafkaTopicsMatch clusterv1.ConditionType = "KafkaTopicsMatch"
+)
+
+// ClowdAppStatus defines the observed state of ClowdApp
+type ClowdAppStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+	// ClowdEnvironmentStatus defines the observed state of ClowdEnvironment
+	Deployments AppResourceStatus     `json:"deployments,omitempty"`
+	Ready       bool                  `json:"ready"`
+	Conditions  []clusterv1.Condition `json:"conditions,omitempty"`
+
+	// The state of the migrations of the ClowdApp.
+	Migrations *MigrationStatus `json:"migrations,omitempty"`
+
+	// The state of the Kafka Connect connectors of the ClowdApp.
+	KafkaConnectors []KafkaConnectorStatus `json:"kafkaConnectors,omitempty"`
+
+	// The differences between the Kafka topics the ClowdApp requests and the
+	// existing KafkaTopics, in app-interface mode.
+	KafkaTopicMismatches []KafkaTopicMismatch `json:"kafkaTopicMismatches,omitempty"`
+}
+
+// KafkaTopicMismatch describes how an existing KafkaTopic differs from the
+// spec a ClowdApp requests for it.
+type KafkaTopicMismatch struct {
+	// The requested name of the topic.
+	TopicName string `json:"topicName"`
+
+	// The field of the topic which differs, such as partitions, replicas or a
+	// config key.
+	Field string `json:"field"`
+
+	// The value the ClowdApp requests.
+	Requested string `json:"requested,omitempty"`
+
+	// The value of the existing KafkaTopic, empty when it is not set.
+	Actual string `json:"actual,omitempty"`
+}
+
+// KafkaConnectorStatus describes the state of a Kafka Connect connector of a
+// ClowdApp.
+type KafkaConnectorStatus struct {
+	// The name of the connector in the ClowdApp.
+	Name string `json:"name"`
+
+	// The name of the KafkaConnector resource.
+	ConnectorName string `json:"connectorName,omitempty"`
+
+	// Whether the connector is ready.
+	Ready bool `json:"ready"`
+
+	// The state of the connector as reported by Kafka Connect, such as
+	// RUNNING, PAUSED or FAILED.
+	State string `json:"state,omitempty"`
+
+	// A message describing why the connector is not ready.
+	Message string `json:"message,omitempty"`
+}
+
+// MigrationStatus describes the state of the migrations of a ClowdApp.
+type MigrationStatus struct {
+	// The name of the job running the migrations for the current image.
+	JobName string `json:"jobName,omitempty"`
+
+	// The image the migrations are run for.
+	Image string `json:"image,omitempty"`
+
+	// The phase of the migrations for the current image, one of Running,
+	// Succeeded or Failed.
+	Phase string `json:"phase,omitempty"`
+
+	// A message describing why the migrations failed.
+	Message string `json:"message,omitempty"`
+
+	// The image the migrations were last applied for.
+	AppliedImage string `json:"appliedImage,omitempty"`
+
+	// The time the migrations were last applied.
+	AppliedTime *metav1.Time `json:"appliedTime,omitempty"`
+}
+
+type AppResourceStatus struct {
+	ManagedDeployments int32 `json:"managedDeployments"`
+	ReadyDeployments   int32 `json:"readyDeployments"`
+
+	// A detailed status of each Deployment or StatefulSet managed by the ClowdApp.
+	DeploymentStatuses []DeploymentStatus `json:"deploymentStatuses,omitempty"`
+
+	// A detailed status of each CronJob managed by the ClowdApp.
+	CronJobStatuses []CronJobStatus `json:"cronJobStatuses,omitempty"`
+}
+
+// DeploymentStatus describes the rollout state of a single Deployment or StatefulSet.
+type DeploymentStatus struct {
+	// The name of the Deployment or StatefulSet resource.
+	Name string `json:"name"`
+
+	// The kind of the resource, either Deployment or StatefulSet.
+	Kind string `json:"kind"`
+
+	// Whether the resource has finished rolling out and is available.
+	Ready bool `json:"ready"`
+
+	// The number of replicas requested in the spec.
+	DesiredReplicas int32 `json:"desiredReplicas"`
+
+	// The number of replicas which are ready.
+	ReadyReplicas int32 `json:"readyReplicas"`
+
+	// The number of replicas running the latest pod template.
+	UpdatedReplicas int32 `json:"updatedReplicas"`
+
+	// The image currently set on the application container.
+	Image string `json:"image,omitempty"`
+
+	// The message of the most recently updated rollout condition.
+	Message string `json:"message,omitempty"`
+
+	// The generation most recently observed by the workload controller.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// CronJobStatus describes the schedule state of a single CronJob.
+type CronJobStatus struct {
+	// The name of the CronJob resource.
+	Name string `json:"name"`
+
+	// The last time the CronJob was scheduled.
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// The last time a job created by the CronJob completed successfully.
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
+
+	// The number of jobs created by the CronJob which are running.
+	Active int32 `json:"active,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=app
+// +kubebuilder:printcolumn:name="Ready",type="integer",JSONPath=".status.deployments.readyDeployments"
+// +kubebuilder:printcolumn:name="Managed",type="integer",JSONPath=".status.deployments.managedDeployments"
+// +kubebuilder:printcolumn:name="EnvName",type="string",JSONPath=".spec.envName"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"DeploymentsReady\")].message"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ClowdApp is the Schema for the clowdapps API
+type ClowdApp struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// A ClowdApp specification.
+	Spec   ClowdAppSpec   `json:"spec,omitempty"`
+	Status ClowdAppStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClowdAppList contains a list of ClowdApp
+type ClowdAppList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// A list of ClowdApp Resources.
+	Items []ClowdApp `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClowdApp{}, &ClowdAppList{})
+}
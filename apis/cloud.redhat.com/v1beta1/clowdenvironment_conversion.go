@@ -0,0 +1,35 @@
+package v1beta1
+
+import (
+	"github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// ConvertTo converts this ClowdEnvironment to the Hub version (v1alpha1).
+func (src *ClowdEnvironment) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.ClowdEnvironment)
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	if err := convertViaJSON(&src.Spec, &dst.Spec); err != nil {
+		return err
+	}
+
+	return convertViaJSON(&src.Status, &dst.Status)
+}
+
+// ConvertFrom converts from the Hub version (v1alpha1) to this version. The deprecated Kafka
+// fields are folded into the cluster and connect stanzas before being dropped.
+func (dst *ClowdEnvironment) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.ClowdEnvironment).DeepCopy()
+
+	src.ConvertDeprecatedKafkaSpec()
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	if err := convertViaJSON(&src.Spec, &dst.Spec); err != nil {
+		return err
+	}
+
+	return convertViaJSON(&src.Status, &dst.Status)
+}
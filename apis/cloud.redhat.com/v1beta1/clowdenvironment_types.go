@@ -0,0 +1,735 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	strimzi "github.com/RedHatInsights/strimzi-client-go/apis/kafka.strimzi.io/v1beta2"
+
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// WebMode details the mode of operation of the Clowder Web Provider
+// +kubebuilder:validation:Enum=none;operator;local
+type WebMode string
+
+// WebImages defines optional container image overrides for the web provider components
+type WebImages struct {
+	// Mock entitlements image -- if not defined, value from operator config is used if set, otherwise a hard-coded default is used.
+	Mocktitlements string `json:"mocktitlements,omitempty"`
+
+	// Keycloak image -- default is 'quay.io/keycloak/keycloak:{KeycloakVersion}' unless overridden here
+	Keycloak string `json:"keycloak,omitempty"`
+
+	// Caddy image -- if not defined, value from operator config is used if set, otherwise a hard-coded default is used.
+	Caddy string `json:"caddy,omitempty"`
+
+	// Mock BOP image -- if not defined, value from operator config is used if set, otherwise a hard-coded default is used.
+	MockBOP string `json:"mockBop,omitempty"`
+}
+
+// WebConfig configures the Clowder provider controlling the creation of web
+// services and their probes.
+type WebConfig struct {
+	// The port that web services inside ClowdApp pods should be served on.
+	Port int32 `json:"port"`
+
+	// The private port that web services inside a ClowdApp should be served on.
+	PrivatePort int32 `json:"privatePort,omitempty"`
+
+	// The auth port that the web local mode will use with the AuthSidecar
+	AuthPort int32 `json:"aiuthPort,omitempty"`
+
+	// An api prefix path that pods will be instructed to use when setting up
+	// their web server.
+	APIPrefix string `json:"apiPrefix,omitempty"`
+
+	// The mode of operation of the Web provider. The allowed modes are
+	// (*_none_*/*_operator_*), and (*_local_*) which deploys keycloak and BOP.
+	Mode WebMode `json:"mode"`
+
+	// The URL of BOP - only used in (*_none_*/*_operator_*) mode.
+	BOPURL string `json:"bopURL,omitempty"`
+
+	// Ingress Class Name used only in (*_local_*) mode.
+	IngressClass string `json:"ingressClass,omitempty"`
+
+	// Optional keycloak version override -- used only in (*_local_*) mode -- if not set, a hard-coded default is used.
+	KeycloakVersion string `json:"keycloakVersion,omitempty"`
+
+	// Optional images to use for web provider components -- only applies when running in (*_local_*) mode.
+	Images WebImages `json:"images,omitempty"`
+
+	// TLS sidecar enablement
+	TLS TLS `json:"tls,omitempty"`
+}
+
+type TLS struct {
+	Enabled     bool  `json:"enabled,omitempty"`
+	Port        int32 `json:"port,omitempty"`
+	PrivatePort int32 `json:"privatePort,omitempty"`
+}
+
+// MetricsMode details the mode of operation of the Clowder Metrics Provider
+// +kubebuilder:validation:Enum=none;operator;app-interface
+type MetricsMode string
+
+type PrometheusConfig struct {
+	// Determines whether to deploy prometheus in operator mode
+	Deploy bool `json:"deploy,omitempty"`
+
+	// Specify prometheus hostname when in app-interface mode
+	AppInterfaceHostname string `json:"appInterfaceHostname,omitempty"`
+}
+
+// MetricsConfig configures the Clowder provider controlling the creation of
+// metrics services and their probes.
+type MetricsConfig struct {
+	// The port that metrics services inside ClowdApp pods should be served on.
+	Port int32 `json:"port"`
+
+	// A prefix path that pods will be instructed to use when setting up their
+	// metrics server.
+	Path string `json:"path,omitempty"`
+
+	// The mode of operation of the Metrics provider. The allowed modes are
+	//  (*_none_*), which disables metrics service generation, or
+	// (*_operator_*) where services and probes are generated.
+	// (*_app-interface_*) where services and probes are generated for app-interface.
+	Mode MetricsMode `json:"mode"`
+
+	// Prometheus specific configuration
+	Prometheus PrometheusConfig `json:"prometheus,omitempty"`
+}
+
+// KafkaMode details the mode of operation of the Clowder Kafka Provider
+// +kubebuilder:validation:Enum=managed-ephem;managed;operator;app-interface;local;none
+type KafkaMode string
+
+// KafkaClusterConfig defines options related to the Kafka cluster managed/monitored by Clowder
+type KafkaClusterConfig struct {
+	// Defines the kafka cluster name (default: <ClowdEnvironment Name>-<UID>)
+	Name string `json:"name,omitempty"`
+
+	// The namespace the kafka cluster is expected to reside in (default: the environment's targetNamespace)
+	Namespace string `json:"namespace,omitempty"`
+
+	// Force TLS
+	ForceTLS bool `json:"forceTLS,omitempty"`
+
+	// The requested number of replicas for kafka/zookeeper. If unset, default is '1'
+	// +kubebuilder:validation:Minimum:=1
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// Persistent volume storage size. If unset, default is '1Gi'
+	// Only applies when KafkaConfig.PVC is set to 'true'
+	StorageSize string `json:"storageSize,omitempty"`
+
+	// Delete persistent volume claim if the Kafka cluster is deleted
+	// Only applies when KafkaConfig.PVC is set to 'true'
+	DeleteClaim bool `json:"deleteClaim,omitempty"`
+
+	// Version. If unset, default is '2.5.0'
+	Version string `json:"version,omitempty"`
+
+	// Config full options
+	Config *map[string]string `json:"config,omitempty"`
+
+	// JVM Options
+	JVMOptions strimzi.KafkaSpecKafkaJvmOptions `json:"jvmOptions,omitempty"`
+
+	// Resource Limits
+	Resources strimzi.KafkaSpecKafkaResources `json:"resources,omitempty"`
+}
+
+// KafkaNamedClusterConfig defines a Kafka cluster of the environment besides the
+// default one, which topics are placed on by naming it in their cluster.
+type KafkaNamedClusterConfig struct {
+	// The name topics use to refer to this cluster.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=63
+	// +kubebuilder:validation:Pattern:="^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+	Name string `json:"name"`
+
+	// The mode of operation of the Clowder Kafka Provider for this cluster, one
+	// of (*_operator_*), (*_app-interface_*) or (*_managed_*).
+	Mode KafkaMode `json:"mode"`
+
+	// Defines options related to this Kafka cluster. If the name is unset, the
+	// cluster is named after the default cluster and the name of this one.
+	Cluster KafkaClusterConfig `json:"cluster,omitempty"`
+
+	// Defines the secret reference for this cluster. Only used in (*_managed_*)
+	// mode.
+	ManagedSecretRef NamespacedName `json:"managedSecretRef,omitempty"`
+
+	// Managed topic prefix for this cluster. Only used in (*_managed_*) mode.
+	ManagedPrefix string `json:"managedPrefix,omitempty"`
+}
+
+// KafkaConnectClusterConfig defines options related to the Kafka Connect cluster managed/monitored by Clowder
+type KafkaConnectClusterConfig struct {
+	// Defines the kafka connect cluster name (default: <kafka cluster's name>)
+	Name string `json:"name,omitempty"`
+
+	// The namespace the kafka connect cluster is expected to reside in (default: the kafka cluster's namespace)
+	Namespace string `json:"namespace,omitempty"`
+
+	// The requested number of replicas for kafka connect. If unset, default is '1'
+	// +kubebuilder:validation:Minimum:=1
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// Version. If unset, default is '2.5.0'
+	Version string `json:"version,omitempty"`
+
+	// Image. If unset, default is 'quay.io/cloudservices/xjoin-kafka-connect-strimzi:latest'
+	Image string `json:"image,omitempty"`
+
+	// Resource Limits
+	Resources strimzi.KafkaConnectSpecResources `json:"resources,omitempty"`
+}
+
+// TopicDeletionPolicy details what happens to a KafkaTopic once no app in the
+// environment declares it anymore
+// +kubebuilder:validation:Enum=retain;delete;delete-after-grace-period
+type TopicDeletionPolicy string
+
+// SchemaRegistryMode details the mode of operation of the schema registry
+// +kubebuilder:validation:Enum=local;app-interface;none
+type SchemaRegistryMode string
+
+// SchemaRegistryConfig configures the schema registry of the Kafka provider.
+type SchemaRegistryConfig struct {
+	// The mode of operation of the schema registry. Valid options are:
+	// (*_local_*) where a Confluent compatible schema registry is deployed next to the
+	// Kafka cluster, only available in (*_operator_*) mode, (*_app-interface_*) where
+	// the URL and credentials of the registry are read from a secret, and (*_none_*)
+	// where no schema registry is given to apps.
+	Mode SchemaRegistryMode `json:"mode,omitempty"`
+
+	// Image. If unset, default is 'docker.io/confluentinc/cp-schema-registry:7.4.0'.
+	// Only used in (*_local_*) mode.
+	Image string `json:"image,omitempty"`
+
+	// Defines the secret holding the url, and optionally the username and password,
+	// of the schema registry. Only used in (*_app-interface_*) mode.
+	SecretRef NamespacedName `json:"secretRef,omitempty"`
+}
+
+// NamespacedName type to represent a real Namespaced Name
+type NamespacedName struct {
+	// Name defines the Name of a resource.
+	Name string `json:"name"`
+
+	// Namespace defines the Namespace of a resource.
+	Namespace string `json:"namespace"`
+}
+
+// KafkaConfig configures the Clowder provider controlling the creation of
+// Kafka instances.
+type KafkaConfig struct {
+	// The mode of operation of the Clowder Kafka Provider. Valid options are:
+	// (*_operator_*) which provisions Strimzi resources and will configure
+	// KafkaTopic CRs and place them in the Kafka cluster's namespace described in the configuration,
+	// (*_app-interface_*) which simply passes the topic names through to the App's
+	// cdappconfig.json and expects app-interface to have created the relevant
+	// topics, and (*_local_*) where a small instance of Kafka is created in the desired cluster namespace
+	// and configured to auto-create topics.
+	Mode KafkaMode `json:"mode"`
+
+	// EnableLegacyStrimzi disables TLS + user auth
+	EnableLegacyStrimzi bool `json:"enableLegacyStrimzi,omitempty"`
+
+	// If using the (*_local_*) or (*_operator_*) mode and PVC is set to true, this sets the provisioned
+	// Kafka instance to use a PVC instead of emptyDir for its volumes.
+	PVC bool `json:"pvc,omitempty"`
+
+	// Defines options related to the Kafka cluster for this environment. Ignored for (*_local_*) mode.
+	Cluster KafkaClusterConfig `json:"cluster,omitempty"`
+
+	// Defines options related to the Kafka Connect cluster for this environment. Ignored for (*_local_*) mode.
+	Connect KafkaConnectClusterConfig `json:"connect,omitempty"`
+
+	// Defines the secret reference for the Managed Kafka mode. Only used in (*_managed_*) mode.
+	ManagedSecretRef NamespacedName `json:"managedSecretRef,omitempty"`
+
+	// Managed topic prefix for the managed cluster. Only used in (*_managed_*) mode.
+	ManagedPrefix string `json:"managedPrefix,omitempty"`
+
+	// Defines the schema registry given to apps using Kafka in this environment.
+	SchemaRegistry SchemaRegistryConfig `json:"schemaRegistry,omitempty"`
+
+	// The policy for the KafkaTopics which no app in the environment declares
+	// anymore, for the topics which do not set their own. Valid options are
+	// (*_retain_*) which keeps the topic until the environment is removed,
+	// (*_delete_*) which deletes it right away, and (*_delete-after-grace-period_*)
+	// which deletes it once it has not been declared for the grace period. If
+	// unset, default is 'retain'. Only used in (*_operator_*) mode.
+	TopicDeletionPolicy TopicDeletionPolicy `json:"topicDeletionPolicy,omitempty"`
+
+	// How long a KafkaTopic is kept once no app declares it when the deletion
+	// policy is (*_delete-after-grace-period_*). If unset, default is '24h'.
+	TopicDeletionGracePeriod *metav1.Duration `json:"topicDeletionGracePeriod,omitempty"`
+
+	// Fails the reconciliation of a ClowdApp when an existing KafkaTopic does
+	// not match the partitions, replicas or config the app requests for it,
+	// instead of only reporting a warning. Only used in (*_app-interface_*)
+	// mode.
+	StrictTopicValidation bool `json:"strictTopicValidation,omitempty"`
+
+	// Defines the Kafka clusters of the environment besides the default one
+	// configured above, which topics can be placed on by name.
+	// +optional
+	Clusters []KafkaNamedClusterConfig `json:"clusters,omitempty"`
+}
+
+// DatabaseMode details the mode of operation of the Clowder Database Provider
+// +kubebuilder:validation:Enum=shared;app-interface;local;none
+type DatabaseMode string
+
+// DatabaseConfig configures the Clowder provider controlling the creation of
+// Database instances.
+type DatabaseConfig struct {
+	// The mode of operation of the Clowder Database Provider. Valid options are:
+	// (*_app-interface_*) where the provider will pass through database credentials
+	// found in the secret defined by the database name in the ClowdApp, and (*_local_*)
+	// where the provider will spin up a local instance of the database.
+	Mode DatabaseMode `json:"mode"`
+
+	// Indicates where Clowder will fetch the database CA certificate bundle from. Currently only used in
+	// (*_app-interface_*) mode. If none is specified, the AWS RDS combined CA bundle is used.
+	// +kubebuilder:validation:Pattern=`^https?:\/\/.+$`
+	CaBundleURL string `json:"caBundleURL,omitempty"`
+
+	// If using the (*_local_*) mode and PVC is set to true, this instructs the local
+	// Database instance to use a PVC instead of emptyDir for its volumes.
+	PVC bool `json:"pvc,omitempty"`
+
+	// Configures scheduled backups of every database instance created by Clowder.
+	// Only used in (*_local_*) and (*_shared_*) modes.
+	Backup *DatabaseBackupConfig `json:"backup,omitempty"`
+
+	// Puts a PgBouncer connection pooler in front of the database of every
+	// ClowdApp. Settings given in the pooler of a ClowdApp take precedence. In
+	// (*_app-interface_*) mode apps without a pooler secret use their database
+	// directly.
+	Pooler *DatabasePooler `json:"pooler,omitempty"`
+}
+
+// DatabaseBackupConfig configures scheduled backups of the database instances
+// created by the Clowder Database Provider.
+type DatabaseBackupConfig struct {
+	// The schedule of the backups in cron format.
+	Schedule string `json:"schedule"`
+
+	// The number of backups to keep for each database instance, defaults to 7.
+	// +kubebuilder:validation:Minimum=1
+	Retention int32 `json:"retention,omitempty"`
+
+	// Defines where the backups are stored, exactly one target must be set.
+	Target DatabaseBackupTarget `json:"target"`
+}
+
+// DatabaseBackupTarget defines where database backups are stored.
+type DatabaseBackupTarget struct {
+	// Stores the backups in a bucket of the MinIO object store of the
+	// ClowdEnvironment, which must be in (*_minio_*) mode.
+	ObjectStore *DatabaseBackupObjectStore `json:"objectStore,omitempty"`
+
+	// Stores the backups on a PersistentVolumeClaim created next to each
+	// database instance.
+	PVC *DatabaseBackupPVC `json:"pvc,omitempty"`
+}
+
+// DatabaseBackupObjectStore defines the bucket database backups are stored in.
+type DatabaseBackupObjectStore struct {
+	// The name of the bucket, which is created if it does not exist.
+	Bucket string `json:"bucket"`
+}
+
+// DatabaseBackupPVC defines the PersistentVolumeClaims database backups are
+// stored on.
+type DatabaseBackupPVC struct {
+	// T-shirt size of the volume holding the backups, one of small, medium, large
+	// +kubebuilder:validation:Enum={"small", "medium", "large"}
+	VolumeSize string `json:"volumeSize,omitempty"`
+}
+
+// LoggingMode details the mode of operation of the Clowder Logging Provider
+// +kubebuilder:validation:Enum=app-interface;null;none
+type LoggingMode string
+
+// LoggingConfig configures the Clowder provider controlling the creation of
+// Logging instances.
+type LoggingConfig struct {
+	// The mode of operation of the Clowder Logging Provider. Valid options are:
+	// (*_app-interface_*) where the provider will pass through cloudwatch credentials
+	// to the app configuration, and (*_none_*) where no logging will be configured.
+	Mode LoggingMode `json:"mode"`
+}
+
+// ServiceMeshMode just determines if we enable or disable the service mesh
+// +kubebuilder:validation:Enum=enabled;disabled
+type ServiceMeshMode string
+
+// ServiceMeshConfig determines if this env should be part of a service mesh
+// and, if enabled, configures the service mesh
+type ServiceMeshConfig struct {
+	Mode ServiceMeshMode `json:"mode,omitempty"`
+}
+
+// ObjectStoreMode details the mode of operation of the Clowder ObjectStore
+// Provider
+// +kubebuilder:validation:Enum=minio;app-interface;none
+type ObjectStoreMode string
+
+// ObjectStoreConfig configures the Clowder provider controlling the creation of
+// ObjectStore instances.
+type ObjectStoreConfig struct {
+	// The mode of operation of the Clowder ObjectStore Provider. Valid options are:
+	// (*_app-interface_*) where the provider will pass through Amazon S3 credentials
+	// to the app configuration, and (*_minio_*) where a local Minio instance will
+	// be created.
+	Mode ObjectStoreMode `json:"mode"`
+
+	// If using the (*_local_*) mode and PVC is set to true, this instructs the local
+	// Database instance to use a PVC instead of emptyDir for its volumes.
+	PVC bool `json:"pvc,omitempty"`
+}
+
+// FeatureFlagsMode details the mode of operation of the Clowder FeatureFlags
+// Provider
+// +kubebuilder:validation:Enum=local;app-interface;none
+// +kubebuilder:validation:Optional
+type FeatureFlagsMode string
+
+// FeatureFlagsConfig configures the Clowder provider controlling the creation of
+// FeatureFlag instances.
+type FeatureFlagsConfig struct {
+	// The mode of operation of the Clowder FeatureFlag Provider. Valid options are:
+	// (*_app-interface_*) where the provider will pass through credentials
+	// to the app configuration, and (*_local_*) where a local Unleash instance will
+	// be created.
+	Mode FeatureFlagsMode `json:"mode,omitempty"`
+
+	// If using the (*_local_*) mode and PVC is set to true, this instructs the local
+	// Database instance to use a PVC instead of emptyDir for its volumes.
+	PVC bool `json:"pvc,omitempty"`
+
+	// Defines the secret containing the client access token, only used for (*_app-interface_*)
+	// mode.
+	CredentialRef NamespacedName `json:"credentialRef,omitempty"`
+
+	// Defines the hostname for (*_app-interface_*) mode
+	Hostname string `json:"hostname,omitempty"`
+
+	// Defineds the port for (*_app-interface_*) mode
+	Port int32 `json:"port,omitempty"`
+}
+
+// InMemoryMode details the mode of operation of the Clowder InMemoryDB
+// Provider
+// +kubebuilder:validation:Enum=redis;app-interface;elasticache;none
+type InMemoryMode string
+
+// InMemoryDBConfig configures the Clowder provider controlling the creation of
+// InMemoryDB instances.
+type InMemoryDBConfig struct {
+	// The mode of operation of the Clowder InMemory Provider. Valid options are:
+	// (*_redis_*) where a local Minio instance will be created, and (*_elasticache_*)
+	// which will search the namespace of the ClowdApp for a secret called 'elasticache'
+	Mode InMemoryMode `json:"mode"`
+
+	// If using the (*_local_*) mode and PVC is set to true, this instructs the local
+	// Database instance to use a PVC instead of emptyDir for its volumes.
+	PVC bool `json:"pvc,omitempty"`
+}
+
+// AutoScaler mode enabled or disabled the autoscaler. The key "keda" is deprecated but preserved for backwards compatibility
+// +kubebuilder:validation:Enum={"none", "enabled", "keda"}
+type AutoScalerMode string
+
+// AutoScalerConfig configures the Clowder provider controlling the creation of
+// AutoScaler configuration.
+type AutoScalerConfig struct {
+	// Enable the autoscaler feature
+	Mode AutoScalerMode `json:"mode,omitempty"`
+}
+
+// Describes what amount of app config is mounted to the pod
+// +kubebuilder:validation:Enum={"none", "app", "", "environment"}
+type ConfigAccessMode string
+
+type TestingConfig struct {
+	// Defines the environment for iqe/smoke testing
+	Iqe IqeConfig `json:"iqe,omitempty"`
+
+	// The mode of operation of the testing Pod. Valid options are:
+	// 'default', 'view' or 'edit'
+	K8SAccessLevel K8sAccessLevel `json:"k8sAccessLevel"`
+
+	// The mode of operation for access to outside app configs. Valid
+	// options are:
+	// (*_none_*) -- no app config is mounted to the pod
+	// (*_app_*) -- only the ClowdApp's config is mounted to the pod
+	// (*_environment_*) -- the config for all apps in the env are mounted
+	ConfigAccess ConfigAccessMode `json:"configAccess"`
+}
+
+type IqeConfig struct {
+	ImageBase string `json:"imageBase"`
+
+	// A pass-through of a resource requirements in k8s ResourceRequirements
+	// format. If omitted, the default resource requirements from the
+	// ClowdEnvironment will be used.
+	Resources core.ResourceRequirements `json:"resources,omitempty"`
+
+	// Defines the secret reference for loading vault credentials into the IQE job
+	VaultSecretRef NamespacedName `json:"vaultSecretRef,omitempty"`
+
+	// Defines configurations related to UI testing containers
+	UI IqeUIConfig `json:"ui,omitempty"`
+}
+
+type IqeUIConfig struct {
+	// Defines configurations for selenium containers in this environment
+	Selenium IqeUISeleniumConfig `json:"selenium,omitempty"`
+}
+
+type IqeUISeleniumConfig struct {
+	// Defines the image used for selenium containers in this environment
+	ImageBase string `json:"imageBase,omitempty"`
+
+	// Defines the default image tag used for selenium containers in this environment
+	DefaultImageTag string `json:"defaultImageTag,omitempty"`
+
+	// Defines the resource requests/limits set on selenium containers
+	Resources core.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// ServiceConfig provides options for k8s Service resources
+type ServiceConfig struct {
+	// +kubebuilder:validation:Enum=ClusterIP;NodePort;""
+	Type string `json:"type"`
+}
+
+// ClowdEnvironmentSpec defines the desired state of ClowdEnvironment.
+type ClowdEnvironmentSpec struct {
+	// TargetNamespace describes the namespace where any generated environmental
+	// resources should end up, this is particularly important in (*_local_*) mode.
+	TargetNamespace string `json:"targetNamespace,omitempty"`
+
+	// A ProvidersConfig object, detailing the setup and configuration of all the
+	// providers used in this ClowdEnvironment.
+	Providers ProvidersConfig `json:"providers"`
+
+	// Defines the default resource requirements in standard k8s format in the
+	// event that they omitted from a PodSpec inside a ClowdApp.
+	ResourceDefaults core.ResourceRequirements `json:"resourceDefaults"`
+
+	ServiceConfig ServiceConfig `json:"serviceConfig,omitempty"`
+
+	// Disabled turns off reconciliation for this ClowdEnv
+	Disabled bool `json:"disabled,omitempty"`
+}
+
+type TokenRefresherConfig struct {
+	// Enables or disables token refresher sidecars
+	Enabled bool `json:"enabled"`
+}
+
+type Sidecars struct {
+	// Sets up Token Refresher configuration
+	TokenRefresher TokenRefresherConfig `json:"tokenRefresher,omitempty"`
+}
+
+type DeploymentConfig struct {
+	OmitPullPolicy bool `json:"omitPullPolicy,omitempty"`
+
+	// TopologySpreadConstraints are applied to the deployments of every ClowdApp which do not
+	// define their own, for example to spread HA services across zones. A constraint without
+	// a labelSelector selects the pods of each deployment.
+	TopologySpreadConstraints []core.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+}
+
+// PodDisruptionBudgetConfig configures the Clowder provider controlling the creation of
+// PodDisruptionBudgets for ClowdApp deployments.
+type PodDisruptionBudgetConfig struct {
+	// Enables the creation of a PodDisruptionBudget for each ClowdApp deployment which can
+	// run more than one replica, including deployments scaled by an autoscaler.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// MaxUnavailable is the number or percentage of pods which can be unavailable during a
+	// disruption, defaults to 1.
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// NetworkPolicyConfig configures the Clowder provider controlling the creation of
+// NetworkPolicies which isolate ClowdApps from each other.
+type NetworkPolicyConfig struct {
+	// Enables the creation of a NetworkPolicy for each ClowdApp deployment, which only
+	// allows ingress from the ClowdApps declaring it as a dependency, from the same
+	// ClowdApp and from the infrastructure of the environment.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// A list of additional namespaces from which ingress is always allowed, for example
+	// the namespace of an ingress controller which is not managed by Clowder.
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+}
+
+// ProvidersConfig defines a group of providers configuration for a ClowdEnvironment.
+type ProvidersConfig struct {
+	// Defines the Configuration for the Clowder Database Provider.
+	Database DatabaseConfig `json:"db,omitempty"`
+
+	// Defines the Configuration for the Clowder InMemoryDB Provider.
+	InMemoryDB InMemoryDBConfig `json:"inMemoryDb"`
+
+	// Defines the Configuration for the Clowder Kafka Provider.
+	Kafka KafkaConfig `json:"kafka"`
+
+	// Defines the Configuration for the Clowder Logging Provider.
+	Logging LoggingConfig `json:"logging"`
+
+	// Defines the Configuration for the Clowder Metrics Provider.
+	Metrics MetricsConfig `json:"metrics,omitempty"`
+
+	// Defines the Configuration for the Clowder ObjectStore Provider.
+	ObjectStore ObjectStoreConfig `json:"objectStore"`
+
+	// Defines the Configuration for the Clowder Web Provider.
+	Web WebConfig `json:"web,omitempty"`
+
+	// Defines the Configuration for the Clowder FeatureFlags Provider.
+	FeatureFlags FeatureFlagsConfig `json:"featureFlags,omitempty"`
+
+	// Defines the Configuration for the Clowder ServiceMesh Provider.
+	ServiceMesh ServiceMeshConfig `json:"serviceMesh,omitempty"`
+
+	// Defines the pull secret to use for the service accounts.
+	PullSecrets []NamespacedName `json:"pullSecrets,omitempty"`
+
+	// Defines the environment for iqe/smoke testing
+	Testing TestingConfig `json:"testing,omitempty"`
+
+	// Defines the sidecar configuration
+	Sidecars Sidecars `json:"sidecars,omitempty"`
+
+	// Defines the autoscaler configuration
+	AutoScaler AutoScalerConfig `json:"autoScaler,omitempty"`
+
+	// Defines the Deployment provider options
+	Deployment DeploymentConfig `json:"deployment,omitempty"`
+
+	// Defines the NetworkPolicy provider options
+	NetworkPolicy NetworkPolicyConfig `json:"networkPolicy,omitempty"`
+
+	// Defines the PodDisruptionBudget provider options
+	PodDisruptionBudget PodDisruptionBudgetConfig `json:"podDisruptionBudget,omitempty"`
+}
+
+// MinioStatus defines the status of a minio instance in local mode.
+type MinioStatus struct {
+	// A reference to standard k8s secret.
+	Credentials core.SecretReference `json:"credentials"`
+
+	// The hostname of a Minio instance.
+	Hostname string `json:"hostname"`
+
+	// The port number the Minio instance is to be served on.
+	Port int32 `json:"port"`
+}
+
+// ClowdEnvironmentStatus defines the observed state of ClowdEnvironment
+type ClowdEnvironmentStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+	Conditions      []clusterv1.Condition     `json:"conditions,omitempty"`
+	TargetNamespace string                    `json:"targetNamespace,omitempty"`
+	Ready           bool                      `json:"ready,omitempty"`
+	Deployments     EnvResourceStatus         `json:"deployments,omitempty"`
+	Apps            []AppInfo                 `json:"apps,omitempty"`
+	Topics          map[string]TopicOwnership `json:"topics,omitempty"`
+	Generation      int64                     `json:"generation,omitempty"`
+	Hostname        string                    `json:"hostname,omitempty"`
+	Prometheus      PrometheusStatus          `json:"prometheus,omitempty"`
+}
+
+type EnvResourceStatus struct {
+	ManagedDeployments int32 `json:"managedDeployments"`
+	ReadyDeployments   int32 `json:"readyDeployments"`
+	ManagedTopics      int32 `json:"managedTopics"`
+	ReadyTopics        int32 `json:"readyTopics"`
+}
+
+// PrometheusStatus provides info on how to connect to Prometheus
+type PrometheusStatus struct {
+	Hostname string `json:"hostname"`
+}
+
+// TopicOwnership lists the apps producing to and consuming from a topic.
+type TopicOwnership struct {
+	Producers []string `json:"producers,omitempty"`
+	Consumers []string `json:"consumers,omitempty"`
+}
+
+// AppInfo details information about a specific app.
+type AppInfo struct {
+	Name        string           `json:"name"`
+	Deployments []DeploymentInfo `json:"deployments"`
+}
+
+// DeploymentInfo defailts information about a specific deployment.
+type DeploymentInfo struct {
+	Name     string `json:"name"`
+	Hostname string `json:"hostname,omitempty"`
+	Port     int32  `json:"port,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=env
+// +kubebuilder:printcolumn:name="Ready",type="integer",JSONPath=".status.deployments.readyDeployments"
+// +kubebuilder:printcolumn:name="Managed",type="integer",JSONPath=".status.deployments.managedDeployments"
+// +kubebuilder:printcolumn:name="Namespace",type="string",JSONPath=".status.targetNamespace"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ClowdEnvironment is the Schema for the clowdenvironments API
+type ClowdEnvironment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// A ClowdEnvironmentSpec object.
+	Spec   ClowdEnvironmentSpec   `json:"spec,omitempty"`
+	Status ClowdEnvironmentStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+
+// ClowdEnvironmentList contains a list of ClowdEnvironment
+type ClowdEnvironmentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// A list of ClowdEnvironment objects.
+	Items []ClowdEnvironment `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClowdEnvironment{}, &ClowdEnvironmentList{})
+}
@@ -0,0 +1,33 @@
+package v1beta1
+
+import (
+	"github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// ConvertTo converts this ClowdJobInvocation to the Hub version (v1alpha1).
+func (src *ClowdJobInvocation) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.ClowdJobInvocation)
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	if err := convertViaJSON(&src.Spec, &dst.Spec); err != nil {
+		return err
+	}
+
+	return convertViaJSON(&src.Status, &dst.Status)
+}
+
+// ConvertFrom converts from the Hub version (v1alpha1) to this version. The deprecated
+// status.jobs list is dropped, the same information is held in status.jobMap.
+func (dst *ClowdJobInvocation) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.ClowdJobInvocation).DeepCopy()
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	if err := convertViaJSON(&src.Spec, &dst.Spec); err != nil {
+		return err
+	}
+
+	return convertViaJSON(&src.Status, &dst.Status)
+}
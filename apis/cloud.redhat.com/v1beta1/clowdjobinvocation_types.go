@@ -0,0 +1,155 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+type JobConditionState string
+
+const (
+	JobInvoked  JobConditionState = "Invoked"
+	JobComplete JobConditionState = "Complete"
+	JobFailed   JobConditionState = "Failed"
+)
+
+type JobTestingSpec struct {
+	// Iqe is the job spec to override defaults from the ClowdApp's
+	// definition of the job
+	Iqe IqeJobSpec `json:"iqe,omitempty"`
+}
+
+type IqeJobSpec struct {
+	// By default, Clowder will set the image on the ClowdJob to be the
+	// baseImage:name-of-iqe-plugin, but only the tag can be overridden here
+	ImageTag string `json:"imageTag,omitempty"`
+
+	// By default, Clowder will use the plugin name indicated in the ClowdApp's
+	// spec.testing.iqePlugin field. A comma,separated,list of plugins can be supplied
+	// here if you wish you override the plugins.
+	IqePlugins string `json:"plugins,omitempty"`
+
+	// Indiciates the presence of a selenium container
+	// Note: currently not implemented
+	UI IqeUISpec `json:"ui,omitempty"`
+
+	// sets the pytest -m args
+	Marker string `json:"marker,omitempty"`
+
+	// sets value for ENV_FOR_DYNACONF
+	DynaconfEnvName string `json:"dynaconfEnvName"`
+
+	// sets pytest -k args
+	Filter string `json:"filter,omitempty"`
+
+	// Use to start the IQE pod without running tests and leave it up so that 'rsh' can be invoked
+	Debug bool `json:"debug,omitempty"`
+
+	// sets values passed to IQE '--requirements' arg
+	Requirements *[]string `json:"requirements,omitempty"`
+
+	// sets values passed to IQE '--requirements-priority' arg
+	RequirementsPriority *[]string `json:"requirementsPriority,omitempty"`
+
+	// sets values passed to IQE '--test-importance' arg
+	TestImportance *[]string `json:"testImportance,omitempty"`
+
+	// sets value for IQE_LOG_LEVEL (default if empty: "info")
+	//+kubebuilder:validation:Enum={"", "critical", "error", "warning", "info", "debug", "notset"}
+	LogLevel string `json:"logLevel,omitempty"`
+
+	// sets value passed to IQE 'IQE_PARALLEL_ENABLED' arg
+	ParallelEnabled string `json:"parallelEnabled,omitempty"`
+
+	// sets value passed to IQE 'IQE_PARALLEL_WORKER_COUNT' arg
+	ParallelWorkerCount string `json:"parallelWorkerCount,omitempty"`
+
+	// sets value passed to IQE 'IQE_RP_ARGS' report portal args
+	RpArgs string `json:"rpArgs,omitempty"`
+
+	// sets value passed to IQE 'IQE_IBUTSU_SOURCE' args
+	IbutsuSource string `json:"ibutsuSource,omitempty"`
+}
+
+type IqeUISpec struct {
+	// No longer used
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Configuration options for running IQE with a selenium container
+	Selenium IqeSeleniumSpec `json:"selenium,omitempty"`
+}
+
+type IqeSeleniumSpec struct {
+	// Whether or not a selenium container should be deployed in the IQE pod
+	Deploy bool `json:"deploy,omitempty"`
+
+	// Name of selenium image tag to use if not using the environment's default
+	ImageTag string `json:"imageTag,omitempty"`
+}
+
+// ClowdJobInvocationSpec defines the desired state of ClowdJobInvocation
+type ClowdJobInvocationSpec struct {
+	// Name of the ClowdApp who owns the jobs
+	AppName string `json:"appName"`
+
+	// Jobs is the set of jobs to be run by the invocation
+	Jobs []string `json:"jobs,omitempty"`
+
+	// Testing is the struct for building out test jobs (iqe, etc) in a CJI
+	Testing JobTestingSpec `json:"testing,omitempty"`
+
+	// RunOnNotReady is a flag that when true, the job will not wait for the deployment to be ready to run
+	RunOnNotReady bool `json:"runOnNotReady,omitempty"`
+}
+
+// ClowdJobInvocationStatus defines the observed state of ClowdJobInvocation
+type ClowdJobInvocationStatus struct {
+	// Completed is false and updated when all jobs have either finished
+	// successfully or failed past their backoff and retry values
+	Completed bool `json:"completed"`
+	// JobMap is a map of the job names run by Job invocation and their outcomes
+	JobMap     map[string]JobConditionState `json:"jobMap"`
+	Conditions []clusterv1.Condition        `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=cji
+// +kubebuilder:printcolumn:name="Completed",type="boolean",JSONPath=".status.completed"
+
+// ClowdJobInvocation is the Schema for the jobinvocations API
+type ClowdJobInvocation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClowdJobInvocationSpec   `json:"spec,omitempty"`
+	Status ClowdJobInvocationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClowdJobInvocationList contains a list of ClowdJobInvocation
+type ClowdJobInvocationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClowdJobInvocation `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClowdJobInvocation{}, &ClowdJobInvocationList{})
+}
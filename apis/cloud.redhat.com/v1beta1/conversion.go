@@ -0,0 +1,39 @@
+package v1beta1
+
+import (
+	"encoding/json"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// convertViaJSON copies src into dst by round tripping it through JSON. The v1beta1 types share
+// their JSON field names with v1alpha1, minus the deprecated fields, so any field that does not
+// exist in the destination version is dropped.
+func convertViaJSON(src interface{}, dst interface{}) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dst)
+}
+
+// SetupWebhookWithManager registers the conversion webhook for ClowdApp.
+func (r *ClowdApp) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+// SetupWebhookWithManager registers the conversion webhook for ClowdEnvironment.
+func (r *ClowdEnvironment) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+// SetupWebhookWithManager registers the conversion webhook for ClowdJobInvocation.
+func (r *ClowdJobInvocation) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
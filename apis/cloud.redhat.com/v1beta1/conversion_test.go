@@ -0,0 +1,115 @@
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestClowdAppConvertFromDeprecated(t *testing.T) {
+	minReplicas := int32(3)
+	src := &v1alpha1.ClowdApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "puptoo", Namespace: "test"},
+		Spec: v1alpha1.ClowdAppSpec{
+			EnvName: "env",
+			Deployments: []v1alpha1.Deployment{{
+				Name:        "processor",
+				MinReplicas: &minReplicas,
+				Web:         true,
+				PodSpec:     v1alpha1.PodSpec{Image: "quay.io/psav/clowder-hello"},
+			}},
+		},
+	}
+
+	dst := &ClowdApp{}
+	assert.NoError(t, dst.ConvertFrom(src))
+
+	assert.Equal(t, "puptoo", dst.Name)
+	assert.Equal(t, "env", dst.Spec.EnvName)
+	assert.Equal(t, int32(3), *dst.Spec.Deployments[0].Replicas)
+	assert.True(t, dst.Spec.Deployments[0].WebServices.Public.Enabled)
+	assert.Equal(t, "quay.io/psav/clowder-hello", dst.Spec.Deployments[0].PodSpec.Image)
+
+	// The hub object must not be modified by the conversion
+	assert.Nil(t, src.Spec.Deployments[0].Replicas)
+
+	hub := &v1alpha1.ClowdApp{}
+	assert.NoError(t, dst.ConvertTo(hub))
+
+	assert.Equal(t, int32(3), *hub.Spec.Deployments[0].GetReplicaCount())
+	assert.Nil(t, hub.Spec.Deployments[0].MinReplicas)
+	assert.True(t, hub.Spec.Deployments[0].WebServices.Public.Enabled)
+}
+
+func TestClowdEnvironmentConvertFromDeprecated(t *testing.T) {
+	src := &v1alpha1.ClowdEnvironment{
+		ObjectMeta: metav1.ObjectMeta{Name: "env"},
+		Spec: v1alpha1.ClowdEnvironmentSpec{
+			TargetNamespace: "test",
+			Providers: v1alpha1.ProvidersConfig{
+				Kafka: v1alpha1.KafkaConfig{
+					Mode:               "operator",
+					ClusterName:        "my-cluster",
+					Namespace:          "kafka",
+					ConnectClusterName: "my-connect",
+					ConnectNamespace:   "connect",
+				},
+				ObjectStore: v1alpha1.ObjectStoreConfig{
+					Mode:   "minio",
+					Suffix: "unused",
+				},
+			},
+		},
+	}
+
+	dst := &ClowdEnvironment{}
+	assert.NoError(t, dst.ConvertFrom(src))
+
+	assert.Equal(t, "test", dst.Spec.TargetNamespace)
+	assert.Equal(t, "my-cluster", dst.Spec.Providers.Kafka.Cluster.Name)
+	assert.Equal(t, "kafka", dst.Spec.Providers.Kafka.Cluster.Namespace)
+	assert.Equal(t, "my-connect", dst.Spec.Providers.Kafka.Connect.Name)
+	assert.Equal(t, "connect", dst.Spec.Providers.Kafka.Connect.Namespace)
+
+	hub := &v1alpha1.ClowdEnvironment{}
+	assert.NoError(t, dst.ConvertTo(hub))
+
+	assert.Equal(t, "my-cluster", hub.Spec.Providers.Kafka.Cluster.Name)
+	assert.Equal(t, "", hub.Spec.Providers.Kafka.ClusterName)
+	assert.Equal(t, "", hub.Spec.Providers.ObjectStore.Suffix)
+	assert.Equal(t, v1alpha1.ObjectStoreMode("minio"), hub.Spec.Providers.ObjectStore.Mode)
+}
+
+func TestClowdJobInvocationConvertFromDeprecated(t *testing.T) {
+	src := &v1alpha1.ClowdJobInvocation{
+		ObjectMeta: metav1.ObjectMeta{Name: "cji", Namespace: "test", Labels: map[string]string{"app": "puptoo"}},
+		Spec: v1alpha1.ClowdJobInvocationSpec{
+			AppName: "puptoo",
+			Jobs:    []string{"hello"},
+		},
+		Status: v1alpha1.ClowdJobInvocationStatus{
+			Completed: true,
+			Jobs:      []string{"puptoo-hello-abcde"},
+			JobMap:    map[string]v1alpha1.JobConditionState{"puptoo-hello-abcde": v1alpha1.JobComplete},
+		},
+	}
+
+	dst := &ClowdJobInvocation{}
+	assert.NoError(t, dst.ConvertFrom(src))
+
+	assert.Equal(t, []string{"hello"}, dst.Spec.Jobs)
+	assert.True(t, dst.Status.Completed)
+	assert.Equal(t, JobComplete, dst.Status.JobMap["puptoo-hello-abcde"])
+
+	// The hub object is left alone when the converted one is changed
+	dst.Labels["app"] = "other"
+	assert.Equal(t, "puptoo", src.Labels["app"])
+
+	hub := &v1alpha1.ClowdJobInvocation{}
+	assert.NoError(t, dst.ConvertTo(hub))
+
+	assert.Nil(t, hub.Status.Jobs)
+	assert.Equal(t, "puptoo", hub.Spec.AppName)
+}
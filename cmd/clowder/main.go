@@ -0,0 +1,153 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	crd "github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1alpha1"
+	controllers "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/yaml"
+)
+
+const usage = `Usage: clowder render [-v] FILE...
+
+Renders the resources and cdappconfig for a ClowdEnvironment and its ClowdApps
+without a cluster. Each FILE may contain several YAML documents, exactly one
+ClowdEnvironment and at least one ClowdApp must be given in total. Use - to
+read from stdin.
+`
+
+func main() {
+	if len(os.Args) < 2 || os.Args[1] != "render" {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	if err := render(os.Args[2:], os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func render(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("render", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	verbose := fs.Bool("v", false, "log provider output to stderr")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return fmt.Errorf("no input files given")
+	}
+
+	log := logr.Discard()
+	if *verbose {
+		log = zap.New(zap.WriteTo(os.Stderr))
+		ctrl.SetLogger(log)
+	}
+
+	var env *crd.ClowdEnvironment
+	var apps []*crd.ClowdApp
+
+	for _, fileName := range fs.Args() {
+		fileEnv, fileApps, err := readFile(fileName)
+		if err != nil {
+			return fmt.Errorf("%s: %w", fileName, err)
+		}
+		if fileEnv != nil {
+			if env != nil {
+				return fmt.Errorf("%s: only one ClowdEnvironment can be rendered", fileName)
+			}
+			env = fileEnv
+		}
+		apps = append(apps, fileApps...)
+	}
+
+	if env == nil {
+		return fmt.Errorf("no ClowdEnvironment given")
+	}
+	if len(apps) == 0 {
+		return fmt.Errorf("no ClowdApp given")
+	}
+
+	result, err := controllers.Render(context.Background(), log, env, apps)
+	if err != nil {
+		return err
+	}
+
+	return controllers.WriteRender(out, result)
+}
+
+func readFile(fileName string) (*crd.ClowdEnvironment, []*crd.ClowdApp, error) {
+	var data []byte
+	var err error
+
+	if fileName == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(fileName)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var env *crd.ClowdEnvironment
+	var apps []*crd.ClowdApp
+
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+	for {
+		doc, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		typeMeta := runtime.TypeMeta{}
+		if err := yaml.Unmarshal(doc, &typeMeta); err != nil {
+			return nil, nil, err
+		}
+
+		switch {
+		case typeMeta.Kind == "":
+			continue
+		case typeMeta.APIVersion != crd.GroupVersion.String():
+			return nil, nil, fmt.Errorf("unsupported apiVersion %s for %s, only %s is supported", typeMeta.APIVersion, typeMeta.Kind, crd.GroupVersion.String())
+		case typeMeta.Kind == "ClowdEnvironment":
+			if env != nil {
+				return nil, nil, fmt.Errorf("only one ClowdEnvironment can be rendered")
+			}
+			env = &crd.ClowdEnvironment{}
+			if err := yaml.UnmarshalStrict(doc, env); err != nil {
+				return nil, nil, err
+			}
+		case typeMeta.Kind == "ClowdApp":
+			app := &crd.ClowdApp{}
+			if err := yaml.UnmarshalStrict(doc, app); err != nil {
+				return nil, nil, err
+			}
+			if app.Namespace == "" {
+				return nil, nil, fmt.Errorf("ClowdApp %s has no namespace", app.Name)
+			}
+			apps = append(apps, app)
+		default:
+			return nil, nil, fmt.Errorf("unsupported kind %s", typeMeta.Kind)
+		}
+	}
+
+	return env, apps, nil
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	out := &bytes.Buffer{}
+
+	err := render([]string{"testdata/render.yaml"}, out)
+
+	assert.NoError(t, err)
+
+	output := out.String()
+
+	assert.Contains(t, output, "kind: Deployment\n")
+	assert.Contains(t, output, "name: puptoo-processor\n")
+	assert.Contains(t, output, "kind: Secret\n")
+	assert.Contains(t, output, "# cdappconfig.json for ClowdApp test-render/puptoo\n")
+	assert.Contains(t, output, "\"publicPort\": 8000")
+}
+
+func TestRenderTwoEnvironments(t *testing.T) {
+	err := render([]string{"testdata/render.yaml", "testdata/render.yaml"}, &bytes.Buffer{})
+
+	assert.EqualError(t, err, "testdata/render.yaml: only one ClowdEnvironment can be rendered")
+}
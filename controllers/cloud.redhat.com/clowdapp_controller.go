@@ -21,7 +21,9 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/prometheus/client_golang/prometheus"
 	apps "k8s.io/api/apps/v1"
+	batch "k8s.io/api/batch/v1"
 	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -32,7 +34,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
@@ -52,11 +56,14 @@ import (
 	_ "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/featureflags"
 	_ "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/inmemorydb"
 	_ "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/iqe"
-	_ "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/kafka"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/kafka"
 	_ "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/logging"
 	_ "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/metrics"
+	_ "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/migrations"
 	_ "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/namespace"
+	_ "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/networkpolicy"
 	_ "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/objectstore"
+	_ "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/poddisruptionbudget"
 	_ "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/pullsecrets"
 	_ "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/serviceaccount"
 	_ "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/servicemesh"
@@ -67,6 +74,7 @@ import (
 	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/errors"
 
 	"github.com/RedHatInsights/rhc-osdk-utils/utils"
+	strimzi "github.com/RedHatInsights/strimzi-client-go/apis/kafka.strimzi.io/v1beta2"
 )
 
 const appFinalizer = "finalizer.app.cloud.redhat.com"
@@ -104,6 +112,7 @@ func (rm *ReconciliationMetrics) stop() {
 // +kubebuilder:rbac:groups="",resources=serviceaccounts;configmaps;services;persistentvolumeclaims;secrets;events;namespaces,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=batch,resources=cronjobs;jobs,verbs=get;list;create;update;watch;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=kafka.strimzi.io,resources=kafkatopics,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=kafka.strimzi.io,resources=kafkas,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=kafka.strimzi.io,resources=kafkausers,verbs=get;list;watch;create;update;patch;delete
@@ -113,9 +122,10 @@ func (rm *ReconciliationMetrics) stop() {
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings;roles,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=monitoring.coreos.com,resources=prometheuses;servicemonitors,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=operators.coreos.com,resources=subscriptions,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=kafka.strimzi.io,resources=kafkaconnectors,verbs=get;list;watch
+// +kubebuilder:rbac:groups=kafka.strimzi.io,resources=kafkaconnectors,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=endpoints;pods,verbs=get;list;watch
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses;networkpolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=config.openshift.io,resources=ingresses,verbs=get;list
 
 // ClowdAppReconciler reconciles a ClowdApp object
@@ -184,10 +194,29 @@ func (r *ClowdAppReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		handler.EnqueueRequestsFromMapFunc(r.appsToEnqueueUponEnvUpdate),
 		builder.WithPredicates(environmentPredicate(r.Log, "app")),
 	)
+	ctrlr.Watches(
+		&source.Kind{Type: &crd.ClowdApp{}},
+		r.dependentAppHandler(),
+		builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+	)
 	ctrlr.Watches(&source.Kind{Type: &apps.Deployment{}}, createNewHandler(deploymentFilter, r.Log, "app", &crd.ClowdApp{}, r.HashCache))
+	ctrlr.Watches(&source.Kind{Type: &apps.StatefulSet{}}, createNewHandler(statefulSetFilter, r.Log, "app", &crd.ClowdApp{}, r.HashCache))
+	ctrlr.Watches(&source.Kind{Type: &batch.CronJob{}}, createNewHandler(cronJobFilter, r.Log, "app", &crd.ClowdApp{}, r.HashCache))
+	ctrlr.Watches(&source.Kind{Type: &batch.Job{}}, createNewHandler(jobFilter, r.Log, "app", &crd.ClowdApp{}, r.HashCache))
 	ctrlr.Watches(&source.Kind{Type: &core.Service{}}, createNewHandler(generationOnlyFilter, r.Log, "app", &crd.ClowdApp{}, r.HashCache))
 	ctrlr.Watches(&source.Kind{Type: &core.ConfigMap{}}, createNewHandler(generationOnlyFilter, r.Log, "app", &crd.ClowdApp{}, r.HashCache))
 	ctrlr.Watches(&source.Kind{Type: &core.Secret{}}, createNewHandler(alwaysFilter, r.Log, "app", &crd.ClowdApp{}, r.HashCache))
+
+	if clowderconfig.LoadedConfig.Features.WatchStrimziResources {
+		// KafkaConnectors are owned by the ClowdEnvironment, they are mapped back to their app
+		// by label to keep the connector states in the ClowdApp status up to date
+		ctrlr.Watches(
+			&source.Kind{Type: &strimzi.KafkaConnector{}},
+			handler.EnqueueRequestsFromMapFunc(r.appsToEnqueueUponConnectorUpdate),
+			builder.WithPredicates(kafkaConnectorPredicate(r.Log, "app")),
+		)
+	}
+
 	ctrlr.WithOptions(controller.Options{
 		RateLimiter: workqueue.NewItemExponentialFailureRateLimiter(time.Duration(500*time.Millisecond), time.Duration(60*time.Second)),
 	})
@@ -240,6 +269,166 @@ func (r *ClowdAppReconciler) appsToEnqueueUponEnvUpdate(a client.Object) []recon
 	return reqs
 }
 
+// appsToEnqueueUponConnectorUpdate returns the ClowdApp a KafkaConnector belongs to. The
+// connector runs in the namespace of the Kafka Connect cluster, so the app is looked up by the
+// names of the app and of its environment the connector is labelled with.
+func (r *ClowdAppReconciler) appsToEnqueueUponConnectorUpdate(a client.Object) []reconcile.Request {
+	reqs := []reconcile.Request{}
+	ctx := context.Background()
+
+	appName := a.GetLabels()[kafka.ConnectorAppLabel]
+	envName := a.GetLabels()["app"]
+	if appName == "" || envName == "" {
+		return reqs
+	}
+
+	appList := crd.ClowdAppList{}
+	if err := r.Client.List(ctx, &appList, client.MatchingFields{"spec.envName": envName}); err != nil {
+		r.Log.Error(err, "Failed to fetch ClowdApps")
+		return nil
+	}
+
+	for _, app := range appList.Items {
+		if app.Name != appName {
+			continue
+		}
+		reqs = append(reqs, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      app.Name,
+				Namespace: app.Namespace,
+			},
+		})
+	}
+
+	logMessage(r.Log, "Reconciliation triggered", "ctrl", "app", "type", "update", "resType", "KafkaConnector", "name", a.GetName(), "namespace", a.GetNamespace())
+
+	return reqs
+}
+
+// dependentAppHandler reconciles the dependencies of a ClowdApp when it changes, as resources such
+// as NetworkPolicies of the dependencies are derived from their dependents. On update, the apps the
+// ClowdApp no longer depends on are reconciled too, so that they drop what they granted it. The
+// apps depending on the ClowdApp are reconciled when its Kafka topics change, as the ACLs of their
+// KafkaUsers are derived from them.
+func (r *ClowdAppReconciler) dependentAppHandler() handler.EventHandler {
+	enqueue := func(q workqueue.RateLimitingInterface, reqs []reconcile.Request) {
+		for _, req := range reqs {
+			q.Add(req)
+		}
+	}
+
+	return handler.Funcs{
+		CreateFunc: func(evt event.CreateEvent, q workqueue.RateLimitingInterface) {
+			enqueue(q, r.appsToEnqueueUponDependentUpdate(evt.Object))
+			enqueue(q, r.appsToEnqueueUponDependencyTopicsUpdate(evt.Object, nil))
+		},
+		UpdateFunc: func(evt event.UpdateEvent, q workqueue.RateLimitingInterface) {
+			enqueue(q, r.appsToEnqueueUponDependentUpdate(evt.ObjectOld, evt.ObjectNew))
+			enqueue(q, r.appsToEnqueueUponDependencyTopicsUpdate(evt.ObjectNew, evt.ObjectOld))
+		},
+		DeleteFunc: func(evt event.DeleteEvent, q workqueue.RateLimitingInterface) {
+			enqueue(q, r.appsToEnqueueUponDependentUpdate(evt.Object))
+			enqueue(q, r.appsToEnqueueUponDependencyTopicsUpdate(evt.Object, nil))
+		},
+	}
+}
+
+// appsToEnqueueUponDependencyTopicsUpdate returns the apps depending on a ClowdApp whose Kafka
+// topics differ from those of its previous version, if any.
+func (r *ClowdAppReconciler) appsToEnqueueUponDependencyTopicsUpdate(obj client.Object, oldObj client.Object) []reconcile.Request {
+	reqs := []reconcile.Request{}
+	ctx := context.Background()
+
+	app, ok := obj.(*crd.ClowdApp)
+	if !ok || app == nil {
+		return reqs
+	}
+
+	oldTopics := []crd.KafkaTopicSpec{}
+	if oldApp, ok := oldObj.(*crd.ClowdApp); ok && oldApp != nil {
+		oldTopics = oldApp.Spec.KafkaTopics
+	}
+	if len(oldTopics) == 0 && len(app.Spec.KafkaTopics) == 0 {
+		return reqs
+	}
+	if oldObj != nil && equality.Semantic.DeepEqual(oldTopics, app.Spec.KafkaTopics) {
+		return reqs
+	}
+
+	appList := crd.ClowdAppList{}
+	if err := r.Client.List(ctx, &appList, client.MatchingFields{"spec.envName": app.Spec.EnvName}); err != nil {
+		r.Log.Error(err, "Failed to fetch ClowdApps")
+		return nil
+	}
+
+	for _, dependent := range appList.Items {
+		if !contains(dependent.Spec.Dependencies, app.Name) && !contains(dependent.Spec.OptionalDependencies, app.Name) {
+			continue
+		}
+		reqs = append(reqs, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      dependent.Name,
+				Namespace: dependent.Namespace,
+			},
+		})
+	}
+
+	if len(reqs) > 0 {
+		logMessage(r.Log, "Reconciliation triggered", "ctrl", "app", "type", "update", "resType", "ClowdApp", "name", app.GetName(), "namespace", app.GetNamespace())
+	}
+
+	return reqs
+}
+
+// appsToEnqueueUponDependentUpdate returns the apps any of the given ClowdApps depend on.
+func (r *ClowdAppReconciler) appsToEnqueueUponDependentUpdate(objs ...client.Object) []reconcile.Request {
+	reqs := []reconcile.Request{}
+	ctx := context.Background()
+
+	// The dependencies of each environment, as the spec of an app may move it to another one
+	envDeps := map[string][]string{}
+	for _, obj := range objs {
+		app, ok := obj.(*crd.ClowdApp)
+		if !ok || app == nil {
+			continue
+		}
+		envDeps[app.Spec.EnvName] = append(envDeps[app.Spec.EnvName], app.Spec.Dependencies...)
+		envDeps[app.Spec.EnvName] = append(envDeps[app.Spec.EnvName], app.Spec.OptionalDependencies...)
+	}
+
+	queued := map[types.NamespacedName]bool{}
+	for envName, deps := range envDeps {
+		if len(deps) == 0 {
+			continue
+		}
+
+		appList := crd.ClowdAppList{}
+		if err := r.Client.List(ctx, &appList, client.MatchingFields{"spec.envName": envName}); err != nil {
+			r.Log.Error(err, "Failed to fetch ClowdApps")
+			continue
+		}
+
+		for _, dep := range appList.Items {
+			nn := types.NamespacedName{
+				Name:      dep.Name,
+				Namespace: dep.Namespace,
+			}
+			if !contains(deps, dep.Name) || queued[nn] {
+				continue
+			}
+			queued[nn] = true
+			reqs = append(reqs, reconcile.Request{NamespacedName: nn})
+		}
+	}
+
+	if len(reqs) > 0 {
+		obj := objs[len(objs)-1]
+		logMessage(r.Log, "Reconciliation triggered", "ctrl", "app", "type", "update", "resType", "ClowdApp", "name", obj.GetName(), "namespace", obj.GetNamespace())
+	}
+
+	return reqs
+}
+
 func contains(list []string, s string) bool {
 	for _, v := range list {
 		if v == s {
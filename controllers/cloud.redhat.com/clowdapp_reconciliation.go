@@ -141,6 +141,10 @@ func (r *ClowdAppReconciliation) isAppMarkedForDeletion() (ctrl.Result, error) {
 }
 
 func (r *ClowdAppReconciliation) finalizeApp() error {
+	if err := r.runProvidersForAppFinalize(); err != nil {
+		return err
+	}
+
 	// We remove it from the managed list because it may have been managed before, but it may not be after this reconcile.
 	delete(managedApps, r.app.GetIdent())
 	managedAppsMetric.Set(float64(len(managedApps)))
@@ -152,6 +156,56 @@ func (r *ClowdAppReconciliation) finalizeApp() error {
 	return nil
 }
 
+// runProvidersForAppFinalize gives every provider implementing providers.AppFinalizer the chance
+// to clean up after the app. Without its environment, or while it is being deleted along with
+// everything the app used, there is nothing left to clean up.
+func (r *ClowdAppReconciliation) runProvidersForAppFinalize() error {
+	env := &crd.ClowdEnvironment{}
+	if err := r.client.Get(r.ctx, types.NamespacedName{Name: r.app.Spec.EnvName}, env); err != nil {
+		if k8serr.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	if env.GetDeletionTimestamp() != nil {
+		r.log.Info("Skipping provider app finalize as the ClowdEnvironment is being deleted", "env", env.Name)
+		return nil
+	}
+
+	cacheConfig := rc.NewCacheConfig(Scheme, nil, ProtectedGVKs, rc.Options{StrictGVK: true, DebugOptions: DebugOptions, Ordering: applyOrder})
+	cache := rc.NewObjectCache(r.ctx, r.client, r.log, cacheConfig)
+
+	provider := providers.Provider{
+		Client: r.client,
+		Ctx:    r.ctx,
+		Env:    env,
+		Cache:  &cache,
+		Log:    *r.log,
+		Config: &config.AppConfig{},
+	}
+
+	for _, provAcc := range providers.ProvidersRegistration.Registry {
+		if !provAcc.FinalizesApps {
+			continue
+		}
+		prov, err := provAcc.SetupProvider(&provider)
+		if err != nil {
+			return errors.Wrap(fmt.Sprintf("getprov: %s", provAcc.Name), err)
+		}
+		finalizer, ok := prov.(providers.AppFinalizer)
+		if !ok {
+			continue
+		}
+		provutils.DebugLog(*r.log, "running provider app finalize:", "name", provAcc.Name, "order", provAcc.Order)
+		if err := finalizer.FinalizeApp(r.app); err != nil {
+			return errors.Wrap(fmt.Sprintf("finalizeapp: %s", provAcc.Name), err)
+		}
+	}
+
+	return nil
+}
+
 func (r *ClowdAppReconciliation) addFinalizer() (ctrl.Result, error) {
 	if !contains(r.app.GetFinalizers(), appFinalizer) {
 		if addFinalizeErr := r.addFinalizerImplementation(); addFinalizeErr != nil {
@@ -53,8 +53,11 @@ import (
 	_ "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/kafka"
 	_ "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/logging"
 	_ "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/metrics"
+	_ "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/migrations"
 	_ "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/namespace"
+	_ "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/networkpolicy"
 	_ "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/objectstore"
+	_ "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/poddisruptionbudget"
 	_ "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/pullsecrets"
 	_ "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/serviceaccount"
 	_ "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/servicemesh"
@@ -179,7 +182,7 @@ func (r *ClowdEnvironmentReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	}
 	managedEnvironments[env.Name] = true
 
-	return ctrl.Result{}, nil
+	return result, nil
 }
 
 func runProvidersForEnv(log logr.Logger, provider providers.Provider) error {
@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"time"
 
 	crd "github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1alpha1"
 	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/clowderconfig"
 	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/kafka"
 	rc "github.com/RedHatInsights/rhc-osdk-utils/resourceCache"
 	"github.com/go-logr/logr"
 	"github.com/prometheus/client_golang/prometheus"
@@ -57,6 +59,8 @@ type ClowdEnvironmentReconciliation struct {
 	env       *crd.ClowdEnvironment
 	log       *logr.Logger
 	oldStatus *crd.ClowdEnvironmentStatus
+	// requeueAfter is how long until the providers need the env to be reconciled again
+	requeueAfter time.Duration
 }
 
 // Returns a list of step methods that should be run during reconciliation
@@ -96,7 +100,7 @@ func (r *ClowdEnvironmentReconciliation) Reconcile() (ctrl.Result, error) {
 		}
 	}
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: r.requeueAfter}, nil
 }
 
 // Determine if app is marked for deletion, and if so finalize and end resonciliation
@@ -286,11 +290,12 @@ func (r *ClowdEnvironmentReconciliation) isTargetNamespaceMarkedForDeletion() (c
 
 func (r *ClowdEnvironmentReconciliation) runProviders() (ctrl.Result, error) {
 	provider := providers.Provider{
-		Ctx:    r.ctx,
-		Client: r.client,
-		Env:    r.env,
-		Cache:  r.cache,
-		Log:    *r.log,
+		Ctx:          r.ctx,
+		Client:       r.client,
+		Env:          r.env,
+		Cache:        r.cache,
+		Log:          *r.log,
+		RequeueAfter: &r.requeueAfter,
 	}
 	provErr := runProvidersForEnv(*r.log, provider)
 
@@ -396,6 +401,7 @@ func (r *ClowdEnvironmentReconciliation) setAppInfoImplementation() error {
 	}
 
 	r.env.Status.Apps = apps
+	r.env.Status.Topics = kafka.GetTopicOwnership(appList)
 	return nil
 }
 
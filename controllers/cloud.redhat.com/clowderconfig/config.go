@@ -13,6 +13,10 @@ type ClowderConfig struct {
 		Keycloak       string `json:"Keycloak"`
 		Mocktitlements string `json:"mocktitlements"`
 		Envoy          string `json:"envoy"`
+		MinioClient    string `json:"minioClient"`
+		PgBouncer      string `json:"pgBouncer"`
+		MySQL          string `json:"mySQL"`
+		MongoDB        string `json:"mongoDB"`
 	} `json:"images"`
 	DebugOptions struct {
 		Logging struct {
@@ -57,12 +61,12 @@ func getConfig() ClowderConfig {
 		configPath = path
 	}
 
-	fmt.Printf("Loading config from: %s\n", configPath)
+	fmt.Fprintf(os.Stderr, "Loading config from: %s\n", configPath)
 
 	jsonData, err := os.ReadFile(configPath)
 
 	if err != nil {
-		fmt.Printf("Config file not found\n")
+		fmt.Fprintf(os.Stderr, "Config file not found\n")
 		return ClowderConfig{}
 	}
 
@@ -70,7 +74,7 @@ func getConfig() ClowderConfig {
 	err = json.Unmarshal(jsonData, &clowderConfig)
 
 	if err != nil {
-		fmt.Printf("Couldn't parse json:\n" + err.Error())
+		fmt.Fprintf(os.Stderr, "Couldn't parse json:\n%s", err.Error())
 		return ClowderConfig{}
 	}
 
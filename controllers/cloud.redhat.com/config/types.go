@@ -249,6 +249,12 @@ type AppConfig struct {
 	// Metadata corresponds to the JSON schema field "metadata".
 	Metadata *AppMetadata `json:"metadata,omitempty" yaml:"metadata,omitempty" mapstructure:"metadata,omitempty"`
 
+	// Mongodb corresponds to the JSON schema field "mongodb".
+	Mongodb *MongoDBConfig `json:"mongodb,omitempty" yaml:"mongodb,omitempty" mapstructure:"mongodb,omitempty"`
+
+	// Mysql corresponds to the JSON schema field "mysql".
+	Mysql *MySQLConfig `json:"mysql,omitempty" yaml:"mysql,omitempty" mapstructure:"mysql,omitempty"`
+
 	// Defines the path to the metrics server that the app should be configured to
 	// listen on for metric traffic.
 	MetricsPath string `json:"metricsPath" yaml:"metricsPath" mapstructure:"metricsPath"`
@@ -323,6 +329,10 @@ type DatabaseConfig struct {
 	// Defines the CA used to access the database.
 	RdsCa *string `json:"rdsCa,omitempty" yaml:"rdsCa,omitempty" mapstructure:"rdsCa,omitempty"`
 
+	// Defines the read replicas of the database, which are accessed with the same
+	// credentials as the database.
+	ReadReplicas []DatabaseReplicaConfig `json:"readReplicas,omitempty" yaml:"readReplicas,omitempty" mapstructure:"readReplicas,omitempty"`
+
 	// Defines the postgres SSL mode that should be used.
 	SslMode string `json:"sslMode" yaml:"sslMode" mapstructure:"sslMode"`
 
@@ -389,6 +399,162 @@ func (j *DatabaseConfig) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// Database Read Replica Configuration
+type DatabaseReplicaConfig struct {
+	// Defines the hostname of the read replica.
+	Hostname string `json:"hostname" yaml:"hostname" mapstructure:"hostname"`
+
+	// Defines the port of the read replica.
+	Port int `json:"port" yaml:"port" mapstructure:"port"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *DatabaseReplicaConfig) UnmarshalJSON(b []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	if v, ok := raw["hostname"]; !ok || v == nil {
+		return fmt.Errorf("field hostname in DatabaseReplicaConfig: required")
+	}
+	if v, ok := raw["port"]; !ok || v == nil {
+		return fmt.Errorf("field port in DatabaseReplicaConfig: required")
+	}
+	type Plain DatabaseReplicaConfig
+	var plain Plain
+	if err := json.Unmarshal(b, &plain); err != nil {
+		return err
+	}
+	*j = DatabaseReplicaConfig(plain)
+	return nil
+}
+
+// MySQL Database Configuration
+type MySQLConfig struct {
+	// Defines the admin password.
+	AdminPassword string `json:"adminPassword" yaml:"adminPassword" mapstructure:"adminPassword"`
+
+	// Defines the admin username.
+	AdminUsername string `json:"adminUsername" yaml:"adminUsername" mapstructure:"adminUsername"`
+
+	// Defines the hostname of the MySQL server configured for the ClowdApp.
+	Hostname string `json:"hostname" yaml:"hostname" mapstructure:"hostname"`
+
+	// Defines the database name.
+	Name string `json:"name" yaml:"name" mapstructure:"name"`
+
+	// Defines the password for the standard user.
+	Password string `json:"password" yaml:"password" mapstructure:"password"`
+
+	// Defines the port of the MySQL server configured for the ClowdApp.
+	Port int `json:"port" yaml:"port" mapstructure:"port"`
+
+	// Defines the CA used to access the database.
+	RdsCa *string `json:"rdsCa,omitempty" yaml:"rdsCa,omitempty" mapstructure:"rdsCa,omitempty"`
+
+	// Defines a username with standard access to the database.
+	Username string `json:"username" yaml:"username" mapstructure:"username"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *MySQLConfig) UnmarshalJSON(b []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	if v, ok := raw["adminPassword"]; !ok || v == nil {
+		return fmt.Errorf("field adminPassword in MySQLConfig: required")
+	}
+	if v, ok := raw["adminUsername"]; !ok || v == nil {
+		return fmt.Errorf("field adminUsername in MySQLConfig: required")
+	}
+	if v, ok := raw["hostname"]; !ok || v == nil {
+		return fmt.Errorf("field hostname in MySQLConfig: required")
+	}
+	if v, ok := raw["name"]; !ok || v == nil {
+		return fmt.Errorf("field name in MySQLConfig: required")
+	}
+	if v, ok := raw["password"]; !ok || v == nil {
+		return fmt.Errorf("field password in MySQLConfig: required")
+	}
+	if v, ok := raw["port"]; !ok || v == nil {
+		return fmt.Errorf("field port in MySQLConfig: required")
+	}
+	if v, ok := raw["username"]; !ok || v == nil {
+		return fmt.Errorf("field username in MySQLConfig: required")
+	}
+	type Plain MySQLConfig
+	var plain Plain
+	if err := json.Unmarshal(b, &plain); err != nil {
+		return err
+	}
+	*j = MySQLConfig(plain)
+	return nil
+}
+
+// MongoDB Database Configuration
+type MongoDBConfig struct {
+	// Defines the admin password.
+	AdminPassword string `json:"adminPassword" yaml:"adminPassword" mapstructure:"adminPassword"`
+
+	// Defines the admin username.
+	AdminUsername string `json:"adminUsername" yaml:"adminUsername" mapstructure:"adminUsername"`
+
+	// Defines the hostname of the MongoDB server configured for the ClowdApp.
+	Hostname string `json:"hostname" yaml:"hostname" mapstructure:"hostname"`
+
+	// Defines the database name.
+	Name string `json:"name" yaml:"name" mapstructure:"name"`
+
+	// Defines the password for the standard user.
+	Password string `json:"password" yaml:"password" mapstructure:"password"`
+
+	// Defines the port of the MongoDB server configured for the ClowdApp.
+	Port int `json:"port" yaml:"port" mapstructure:"port"`
+
+	// Defines the CA used to access the database.
+	RdsCa *string `json:"rdsCa,omitempty" yaml:"rdsCa,omitempty" mapstructure:"rdsCa,omitempty"`
+
+	// Defines a username with standard access to the database.
+	Username string `json:"username" yaml:"username" mapstructure:"username"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *MongoDBConfig) UnmarshalJSON(b []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	if v, ok := raw["adminPassword"]; !ok || v == nil {
+		return fmt.Errorf("field adminPassword in MongoDBConfig: required")
+	}
+	if v, ok := raw["adminUsername"]; !ok || v == nil {
+		return fmt.Errorf("field adminUsername in MongoDBConfig: required")
+	}
+	if v, ok := raw["hostname"]; !ok || v == nil {
+		return fmt.Errorf("field hostname in MongoDBConfig: required")
+	}
+	if v, ok := raw["name"]; !ok || v == nil {
+		return fmt.Errorf("field name in MongoDBConfig: required")
+	}
+	if v, ok := raw["password"]; !ok || v == nil {
+		return fmt.Errorf("field password in MongoDBConfig: required")
+	}
+	if v, ok := raw["port"]; !ok || v == nil {
+		return fmt.Errorf("field port in MongoDBConfig: required")
+	}
+	if v, ok := raw["username"]; !ok || v == nil {
+		return fmt.Errorf("field username in MongoDBConfig: required")
+	}
+	type Plain MongoDBConfig
+	var plain Plain
+	if err := json.Unmarshal(b, &plain); err != nil {
+		return err
+	}
+	*j = MongoDBConfig(plain)
+	return nil
+}
+
 const BrokerConfigAuthtypeSasl BrokerConfigAuthtype = "sasl"
 
 // UnmarshalJSON implements json.Unmarshaler.
@@ -444,6 +610,13 @@ type KafkaConfig struct {
 	// Defines the brokers the app should connect to for Kafka services.
 	Brokers []BrokerConfig `json:"brokers" yaml:"brokers" mapstructure:"brokers"`
 
+	// The prefix every consumer group of the app must start with, when the Kafka user of the app
+	// is only allowed its own consumer groups.
+	ConsumerGroupPrefix *string `json:"consumerGroupPrefix,omitempty" yaml:"consumerGroupPrefix,omitempty" mapstructure:"consumerGroupPrefix,omitempty"`
+
+	// Defines the schema registry the app should use for Kafka message schemas.
+	SchemaRegistry *SchemaRegistryConfig `json:"schemaRegistry,omitempty" yaml:"schemaRegistry,omitempty" mapstructure:"schemaRegistry,omitempty"`
+
 	// Defines a list of the topic configurations available to the application.
 	Topics []TopicConfig `json:"topics" yaml:"topics" mapstructure:"topics"`
 }
@@ -469,6 +642,36 @@ func (j *KafkaConfig) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// Schema Registry Configuration
+type SchemaRegistryConfig struct {
+	// Defines the password of the schema registry.
+	Password *string `json:"password,omitempty" yaml:"password,omitempty" mapstructure:"password,omitempty"`
+
+	// Defines the URL of the schema registry.
+	Url string `json:"url" yaml:"url" mapstructure:"url"`
+
+	// Defines the username of the schema registry.
+	Username *string `json:"username,omitempty" yaml:"username,omitempty" mapstructure:"username,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *SchemaRegistryConfig) UnmarshalJSON(b []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	if v, ok := raw["url"]; !ok || v == nil {
+		return fmt.Errorf("field url in SchemaRegistryConfig: required")
+	}
+	type Plain SchemaRegistryConfig
+	var plain Plain
+	if err := json.Unmarshal(b, &plain); err != nil {
+		return err
+	}
+	*j = SchemaRegistryConfig(plain)
+	return nil
+}
+
 // Cloud Watch configuration
 type CloudWatchConfig struct {
 	// Defines the access key that the app should use for configuring CloudWatch.
@@ -684,11 +887,31 @@ type PrivateDependencyEndpoint struct {
 
 // Topic Configuration
 type TopicConfig struct {
+	// Defines the brokers the app should connect to for the topic, when it is not on
+	// the default Kafka cluster.
+	Brokers []BrokerConfig `json:"brokers,omitempty" yaml:"brokers,omitempty" mapstructure:"brokers,omitempty"`
+
+	// The name of the Kafka cluster of the topic, when it is not on the default Kafka
+	// cluster.
+	Cluster *string `json:"cluster,omitempty" yaml:"cluster,omitempty" mapstructure:"cluster,omitempty"`
+
+	// The consumer group the app consumes the topic with.
+	ConsumerGroupName *string `json:"consumerGroupName,omitempty" yaml:"consumerGroupName,omitempty" mapstructure:"consumerGroupName,omitempty"`
+
+	// The name on the Kafka server of the dead letter topic of the topic.
+	DeadLetterTopicName *string `json:"deadLetterTopicName,omitempty" yaml:"deadLetterTopicName,omitempty" mapstructure:"deadLetterTopicName,omitempty"`
+
 	// The name of the actual topic on the Kafka server.
 	Name string `json:"name" yaml:"name" mapstructure:"name"`
 
 	// The name that the app requested in the ClowdApp definition.
 	RequestedName string `json:"requestedName" yaml:"requestedName" mapstructure:"requestedName"`
+
+	// The name on the Kafka server of the retry topic of the topic.
+	RetryTopicName *string `json:"retryTopicName,omitempty" yaml:"retryTopicName,omitempty" mapstructure:"retryTopicName,omitempty"`
+
+	// Whether the app produces to, consumes from, or does both with the topic.
+	Role *string `json:"role,omitempty" yaml:"role,omitempty" mapstructure:"role,omitempty"`
 }
 
 var enumValues_BrokerConfigAuthtype = []interface{}{
@@ -9,6 +9,8 @@ import (
 	strimzi "github.com/RedHatInsights/strimzi-client-go/apis/kafka.strimzi.io/v1beta2"
 	"github.com/go-logr/logr"
 	apps "k8s.io/api/apps/v1"
+	batch "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
@@ -66,6 +68,53 @@ func deploymentUpdateFunc(e event.UpdateEvent) bool {
 	return false
 }
 
+func statefulSetUpdateFunc(e event.UpdateEvent) bool {
+	objOld := e.ObjectOld.(*apps.StatefulSet)
+	objNew := e.ObjectNew.(*apps.StatefulSet)
+	if objNew.GetGeneration() != objOld.GetGeneration() {
+		return true
+	}
+	if (objOld.Status.ReadyReplicas != objNew.Status.ReadyReplicas) && (objNew.Status.ReadyReplicas == objNew.Status.Replicas) {
+		return true
+	}
+	if (objOld.Status.ReadyReplicas == objOld.Status.Replicas) && (objNew.Status.ReadyReplicas != objNew.Status.Replicas) {
+		return true
+	}
+	return false
+}
+
+func cronJobUpdateFunc(e event.UpdateEvent) bool {
+	objOld := e.ObjectOld.(*batch.CronJob)
+	objNew := e.ObjectNew.(*batch.CronJob)
+	if objNew.GetGeneration() != objOld.GetGeneration() {
+		return true
+	}
+	// The schedule and success times and the number of active jobs are surfaced in the ClowdApp status
+	if !objOld.Status.LastSuccessfulTime.Equal(objNew.Status.LastSuccessfulTime) {
+		return true
+	}
+	if !objOld.Status.LastScheduleTime.Equal(objNew.Status.LastScheduleTime) {
+		return true
+	}
+	if len(objOld.Status.Active) != len(objNew.Status.Active) {
+		return true
+	}
+	return false
+}
+
+func jobUpdateFunc(e event.UpdateEvent) bool {
+	objOld := e.ObjectOld.(*batch.Job)
+	objNew := e.ObjectNew.(*batch.Job)
+	if objNew.GetGeneration() != objOld.GetGeneration() {
+		return true
+	}
+	// Only finished jobs change the ClowdApp status, running pods are not worth a reconcile
+	if objOld.Status.Succeeded != objNew.Status.Succeeded || objOld.Status.Failed != objNew.Status.Failed {
+		return true
+	}
+	return false
+}
+
 func kafkaUpdateFunc(e event.UpdateEvent) bool {
 	objOld := e.ObjectOld.(*strimzi.Kafka)
 	objNew := e.ObjectNew.(*strimzi.Kafka)
@@ -75,6 +124,16 @@ func kafkaUpdateFunc(e event.UpdateEvent) bool {
 	return false
 }
 
+func kafkaConnectorUpdateFunc(e event.UpdateEvent) bool {
+	objOld := e.ObjectOld.(*strimzi.KafkaConnector)
+	objNew := e.ObjectNew.(*strimzi.KafkaConnector)
+	if objOld.GetGeneration() != objNew.GetGeneration() {
+		return true
+	}
+	// The state of the connector is surfaced in the ClowdApp status
+	return !equality.Semantic.DeepEqual(objOld.Status, objNew.Status)
+}
+
 func environmentUpdateFunc(e event.UpdateEvent) bool {
 	objOld := e.ObjectOld.(*crd.ClowdEnvironment)
 	objNew := e.ObjectNew.(*crd.ClowdEnvironment)
@@ -114,10 +173,37 @@ func deploymentFilter(logr logr.Logger, ctrlName string) HandlerFuncs {
 	return genFilterFunc(deploymentUpdateFunc, logr, ctrlName)
 }
 
+func statefulSetFilter(logr logr.Logger, ctrlName string) HandlerFuncs {
+	return genFilterFunc(statefulSetUpdateFunc, logr, ctrlName)
+}
+
+func cronJobFilter(logr logr.Logger, ctrlName string) HandlerFuncs {
+	return genFilterFunc(cronJobUpdateFunc, logr, ctrlName)
+}
+
+func jobFilter(logr logr.Logger, ctrlName string) HandlerFuncs {
+	return genFilterFunc(jobUpdateFunc, logr, ctrlName)
+}
+
 func kafkaFilter(logr logr.Logger, ctrlName string) HandlerFuncs {
 	return genFilterFunc(kafkaUpdateFunc, logr, ctrlName)
 }
 
+func kafkaConnectorPredicate(_ logr.Logger, _ string) predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return true
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return true
+		},
+		UpdateFunc: kafkaConnectorUpdateFunc,
+		GenericFunc: func(e event.GenericEvent) bool {
+			return true
+		},
+	}
+}
+
 func environmentPredicate(_ logr.Logger, _ string) predicate.Predicate {
 	return predicate.Funcs{
 		CreateFunc: func(e event.CreateEvent) bool {
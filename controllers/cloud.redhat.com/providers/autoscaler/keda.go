@@ -8,7 +8,6 @@ import (
 	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers"
 	deployProvider "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/deployment"
 	keda "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
-	apps "k8s.io/api/apps/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
 
@@ -19,12 +18,12 @@ func makeAutoScalers(deployment *crd.Deployment, app *crd.ClowdApp, c *config.Ap
 		return err
 	}
 
-	d := &apps.Deployment{}
-	if err := asp.Cache.Get(deployProvider.CoreDeployment, d, nn); err != nil {
+	w, err := deployProvider.GetWorkload(asp.Cache, app, deployment)
+	if err != nil {
 		return err
 	}
 
-	initAutoScaler(asp.Env, app, d, s, nn, deployment, c)
+	initAutoScaler(asp.Env, app, w, s, nn, deployment, c)
 
 	return asp.Cache.Update(CoreAutoScaler, s)
 }
@@ -34,14 +33,14 @@ func ProvideKedaAutoScaler(app *crd.ClowdApp, c *config.AppConfig, asp *provider
 	return err
 }
 
-func initAutoScaler(env *crd.ClowdEnvironment, app *crd.ClowdApp, d *apps.Deployment, s *keda.ScaledObject, nn types.NamespacedName, deployment *crd.Deployment, c *config.AppConfig) {
+func initAutoScaler(env *crd.ClowdEnvironment, app *crd.ClowdApp, w *deployProvider.Workload, s *keda.ScaledObject, nn types.NamespacedName, deployment *crd.Deployment, c *config.AppConfig) {
 	labels := app.GetLabels()
 	labels["pod"] = nn.Name
 	app.SetObjectMeta(s, crd.Name(nn.Name), crd.Labels(labels))
 
-	// Set up the watcher to watch the Deployment we created earlier.
+	// Set up the watcher to watch the Deployment or StatefulSet we created earlier.
 	scalerSpec := keda.ScaledObjectSpec{
-		ScaleTargetRef:  &keda.ScaleTarget{Name: d.Name, Kind: d.Kind, APIVersion: d.APIVersion},
+		ScaleTargetRef:  &keda.ScaleTarget{Name: w.Object.GetName(), Kind: w.Kind(), APIVersion: w.APIVersion()},
 		PollingInterval: deployment.AutoScaler.PollingInterval,
 		CooldownPeriod:  deployment.AutoScaler.CooldownPeriod,
 		Advanced:        deployment.AutoScaler.Advanced,
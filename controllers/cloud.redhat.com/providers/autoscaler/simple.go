@@ -10,7 +10,6 @@ import (
 	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/errors"
 	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers"
 	deployProvider "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/deployment"
-	apps "k8s.io/api/apps/v1"
 	v2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -25,11 +24,11 @@ const (
 
 // Creates a simple HPA in the resource cache for the deployment and ClowdApp
 func ProvideSimpleAutoScaler(app *crd.ClowdApp, appConfig *config.AppConfig, sp *providers.Provider, deployment crd.Deployment) error {
-	cachedDeployment, err := getDeploymentFromCache(&deployment, app, sp)
+	workload, err := getWorkloadFromCache(&deployment, app, sp)
 	if err != nil {
 		return errors.Wrap("Could not get deployment from resource cache", err)
 	}
-	hpaMaker := newSimpleHPAMaker(&deployment, app, appConfig, cachedDeployment)
+	hpaMaker := newSimpleHPAMaker(&deployment, app, appConfig, workload)
 	hpaResource := hpaMaker.getResource()
 
 	err = cacheAutoscaler(app, sp, deployment, hpaResource)
@@ -46,33 +45,28 @@ func cacheAutoscaler(app *crd.ClowdApp, sp *providers.Provider, deployment crd.D
 	return sp.Cache.Create(SimpleAutoScaler, nn, &hpaResource)
 }
 
-// Get the core apps.Deployment from the provider cache
-func getDeploymentFromCache(clowdDeployment *crd.Deployment, app *crd.ClowdApp, sp *providers.Provider) (*apps.Deployment, error) {
-	nn := app.GetDeploymentNamespacedName(clowdDeployment)
-	d := &apps.Deployment{}
-	if err := sp.Cache.Get(deployProvider.CoreDeployment, d, nn); err != nil {
-		return d, err
-	}
-	return d, nil
+// Get the core workload (Deployment or StatefulSet) from the provider cache
+func getWorkloadFromCache(clowdDeployment *crd.Deployment, app *crd.ClowdApp, sp *providers.Provider) (*deployProvider.Workload, error) {
+	return deployProvider.GetWorkload(sp.Cache, app, clowdDeployment)
 }
 
 // Factory for the simpleHPAMaker
-func newSimpleHPAMaker(deployment *crd.Deployment, app *crd.ClowdApp, appConfig *config.AppConfig, coreDeployment *apps.Deployment) simpleHPAMaker {
+func newSimpleHPAMaker(deployment *crd.Deployment, app *crd.ClowdApp, appConfig *config.AppConfig, workload *deployProvider.Workload) simpleHPAMaker {
 	return simpleHPAMaker{
-		deployment:     deployment,
-		app:            app,
-		appConfig:      appConfig,
-		coreDeployment: coreDeployment,
+		deployment: deployment,
+		app:        app,
+		appConfig:  appConfig,
+		workload:   workload,
 	}
 }
 
 // Creates a simple HPA and stores references
 // to the resources and dependencies it requires
 type simpleHPAMaker struct {
-	deployment     *crd.Deployment
-	app            *crd.ClowdApp
-	appConfig      *config.AppConfig
-	coreDeployment *apps.Deployment
+	deployment *crd.Deployment
+	app        *crd.ClowdApp
+	appConfig  *config.AppConfig
+	workload   *deployProvider.Workload
 }
 
 // Constructs the HPA in 2 parts: the HPA itself and the metric spec
@@ -96,13 +90,13 @@ func (d *simpleHPAMaker) makeHPA() v2.HorizontalPodAutoscaler {
 					UID:        d.app.UID,
 				}},
 			Name:      name,
-			Namespace: d.coreDeployment.Namespace,
+			Namespace: d.workload.Object.GetNamespace(),
 		},
 		Spec: v2.HorizontalPodAutoscalerSpec{
 			ScaleTargetRef: v2.CrossVersionObjectReference{
-				APIVersion: DeploymentAPIVersion,
-				Kind:       DeploymentKind,
-				Name:       d.coreDeployment.Name,
+				APIVersion: d.workload.APIVersion(),
+				Kind:       d.workload.Kind(),
+				Name:       d.workload.Object.GetName(),
 			},
 			MinReplicas: &d.deployment.AutoScalerSimple.Replicas.Min,
 			MaxReplicas: d.deployment.AutoScalerSimple.Replicas.Max,
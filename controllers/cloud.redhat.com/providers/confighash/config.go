@@ -9,7 +9,6 @@ import (
 	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/errors"
 	deployProvider "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/deployment"
 	"github.com/RedHatInsights/rhc-osdk-utils/utils"
-	apps "k8s.io/api/apps/v1"
 	core "k8s.io/api/core/v1"
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
@@ -105,8 +104,8 @@ func (ch *confighashProvider) volSecret(app *crd.ClowdApp, volume core.Volume) e
 	return ch.HashCache.AddClowdObjectToObject(app, sec)
 }
 
-func (ch *confighashProvider) iterateEnvVars(app *crd.ClowdApp, deployment apps.Deployment) error {
-	for _, cont := range deployment.Spec.Template.Spec.Containers {
+func (ch *confighashProvider) iterateEnvVars(app *crd.ClowdApp, template *core.PodTemplateSpec) error {
+	for _, cont := range template.Spec.Containers {
 		for _, env := range cont.Env {
 			if err := ch.envConfigMap(app, env); err != nil {
 				return err
@@ -120,8 +119,8 @@ func (ch *confighashProvider) iterateEnvVars(app *crd.ClowdApp, deployment apps.
 	return nil
 }
 
-func (ch *confighashProvider) iterateVolumes(app *crd.ClowdApp, deployment apps.Deployment) error {
-	for _, volume := range deployment.Spec.Template.Spec.Volumes {
+func (ch *confighashProvider) iterateVolumes(app *crd.ClowdApp, template *core.PodTemplateSpec) error {
+	for _, volume := range template.Spec.Volumes {
 		if err := ch.volConfigMap(app, volume); err != nil {
 			return err
 		}
@@ -133,13 +132,12 @@ func (ch *confighashProvider) iterateVolumes(app *crd.ClowdApp, deployment apps.
 	return nil
 }
 
-func (ch *confighashProvider) updateHashCache(dList *apps.DeploymentList, app *crd.ClowdApp) error {
-	for _, deployment := range dList.Items {
-		deploy := deployment
-		if err := ch.iterateEnvVars(app, deploy); err != nil {
+func (ch *confighashProvider) updateHashCache(workloads []*deployProvider.Workload, app *crd.ClowdApp) error {
+	for _, workload := range workloads {
+		if err := ch.iterateEnvVars(app, workload.Template); err != nil {
 			return err
 		}
-		if err := ch.iterateVolumes(app, deploy); err != nil {
+		if err := ch.iterateVolumes(app, workload.Template); err != nil {
 			return err
 		}
 	}
@@ -156,12 +154,12 @@ func (ch *confighashProvider) persistConfig(app *crd.ClowdApp) (string, error) {
 		return "", err
 	}
 
-	dList := apps.DeploymentList{}
-	if err := ch.Cache.List(deployProvider.CoreDeployment, &dList); err != nil {
+	workloads, err := deployProvider.ListWorkloads(ch.Cache)
+	if err != nil {
 		return "", err
 	}
 
-	if err := ch.updateHashCache(&dList, app); err != nil {
+	if err := ch.updateHashCache(workloads, app); err != nil {
 		return "", err
 	}
 
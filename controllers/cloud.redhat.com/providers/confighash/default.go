@@ -5,7 +5,6 @@ import (
 	p "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers"
 	cronjobProvider "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/cronjob"
 	deployProvider "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/deployment"
-	apps "k8s.io/api/apps/v1"
 	batch "k8s.io/api/batch/v1"
 	core "k8s.io/api/core/v1"
 
@@ -38,17 +37,16 @@ func (ch *confighashProvider) Provide(app *crd.ClowdApp) error {
 		return err
 	}
 
-	dList := apps.DeploymentList{}
-	if err := ch.Cache.List(deployProvider.CoreDeployment, &dList); err != nil {
+	workloads, err := deployProvider.ListWorkloads(ch.Cache)
+	if err != nil {
 		return err
 	}
 
-	for _, deployment := range dList.Items {
-		depInner := deployment
+	for _, workload := range workloads {
 		annotations := map[string]string{"configHash": hash}
-		utils.UpdateAnnotations(&depInner.Spec.Template, annotations)
+		utils.UpdateAnnotations(workload.Template, annotations)
 
-		if err := ch.Cache.Update(deployProvider.CoreDeployment, &depInner); err != nil {
+		if err := workload.Update(ch.Cache); err != nil {
 			return err
 		}
 	}
@@ -97,17 +97,6 @@ func buildPodTemplate(app *crd.ClowdApp, env *crd.ClowdEnvironment, pt *core.Pod
 		c.ImagePullPolicy = core.PullIfNotPresent
 	}
 
-	if pod.MachinePool != "" {
-		pt.Spec.Tolerations = []core.Toleration{{
-			Key:      pod.MachinePool,
-			Effect:   core.TaintEffectNoSchedule,
-			Operator: core.TolerationOpEqual,
-			Value:    "true",
-		}}
-	} else {
-		pt.Spec.Tolerations = []core.Toleration{}
-	}
-
 	// set service account for pod
 	pt.Spec.ServiceAccountName = app.GetClowdSAName()
 	pt.Spec.TerminationGracePeriodSeconds = utils.Int64Ptr(30)
@@ -128,6 +117,7 @@ func buildPodTemplate(app *crd.ClowdApp, env *crd.ClowdEnvironment, pt *core.Pod
 	})
 
 	pt.Spec.Containers = []core.Container{c}
+	pt.Spec.Containers = append(pt.Spec.Containers, deployProvider.ProcessSidecars(&c, &pod, env)...)
 
 	ics, err := deployProvider.ProcessInitContainers(nn, &c, pod.InitContainers)
 
@@ -156,6 +146,7 @@ func buildPodTemplate(app *crd.ClowdApp, env *crd.ClowdEnvironment, pt *core.Pod
 	}
 
 	deployProvider.ApplyPodAntiAffinity(pt)
+	deployProvider.ProcessScheduling(&pod, pt)
 
 	return nil
 }
@@ -211,5 +202,6 @@ func applyCronJob(app *crd.ClowdApp, cj *batch.CronJob, pt *core.PodTemplateSpec
 	} // implicit else => default is 1
 
 	deployProvider.ApplyPodAntiAffinity(&cj.Spec.JobTemplate.Spec.Template)
+	deployProvider.ProcessScheduling(&cronjob.PodSpec, &cj.Spec.JobTemplate.Spec.Template)
 
 }
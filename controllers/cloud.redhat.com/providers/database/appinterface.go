@@ -87,9 +87,18 @@ func (a *appInterface) Provide(app *crd.ClowdApp) error {
 		return errors.NewClowderError("Cannot set dbName & shared db app name")
 	}
 
+	if app.Spec.Database.Seed != nil {
+		return errors.NewClowderError("Database seeding is not supported in app-interface mode")
+	}
+
+	if app.Spec.Database.ReadReplicas != 0 {
+		return errors.NewClowderError("Read replicas are provided by secrets in app-interface mode")
+	}
+
 	var dbSpec crd.DatabaseSpec
 	var namespace string
 	var searchAppName string
+	dbApp := app
 
 	if app.Spec.Database.Name != "" {
 		dbSpec = app.Spec.Database
@@ -110,6 +119,7 @@ func (a *appInterface) Provide(app *crd.ClowdApp) error {
 		dbSpec = refApp.Spec.Database
 		namespace = refApp.Namespace
 		searchAppName = refApp.Name
+		dbApp = refApp
 	}
 
 	rdsCaBundleURL := a.Env.Spec.Providers.Database.CaBundleURL
@@ -119,7 +129,46 @@ func (a *appInterface) Provide(app *crd.ClowdApp) error {
 		return err
 	}
 
-	a.Config.Database = &matched.Config
+	if getPoolerSpec(a.Env, dbApp) != nil {
+		// Only apps asking for a pooler themselves wait for its secret, the pooler of the
+		// environment is used by the apps it has been provided for
+		required := dbApp.Spec.Database.Pooler != nil
+		if err := a.usePoolerSecret(namespace, searchAppName, required, &matched.Config); err != nil {
+			return err
+		}
+	}
+
+	setEngineConfig(a.Config, getEngine(dbApp), &matched.Config)
+
+	return nil
+}
+
+// usePoolerSecret points the config at the pooler provided for the app by a
+// secret annotated with clowder/database-pooler. When the secret is not
+// required the config is left pointing at the database if there is none.
+func (a *appInterface) usePoolerSecret(namespace, searchAppName string, required bool, dbCfg *config.DatabaseConfig) error {
+	secrets := core.SecretList{}
+	if err := a.Client.List(a.Ctx, &secrets, client.InNamespace(namespace)); err != nil {
+		msg := fmt.Sprintf("Failed to list secrets in %s", namespace)
+		return errors.Wrap(msg, err)
+	}
+
+	sort.Slice(secrets.Items, func(i, j int) bool {
+		return secrets.Items[i].Name < secrets.Items[j].Name
+	})
+
+	found, err := getPoolerConfig(secrets.Items, searchAppName, dbCfg)
+	if err != nil {
+		return err
+	}
+
+	if !found && required {
+		missingDep := errors.MakeMissingDependencies(errors.MissingDependency{
+			Source:  "database",
+			Details: fmt.Sprintf("DB pooler secret matching app '%s' not found in namespace '%s'", searchAppName, namespace),
+		})
+		return &missingDep
+	}
 
 	return nil
 }
@@ -157,7 +206,7 @@ func GetDbConfig(
 
 		matched = resolveDb(dbSpec, dbConfigs)
 
-		if matched == (config.DatabaseConfigContainer{}) {
+		if matched.Ref == (types.NamespacedName{}) {
 			missingDep := errors.MakeMissingDependencies(errors.MissingDependency{
 				Source:  "database",
 				Details: fmt.Sprintf("DB secret matching app '%s' not found in namespace '%s'", searchAppName, namespace),
@@ -168,6 +217,8 @@ func GetDbConfig(
 		matched = matches[0]
 	}
 
+	matched.Config.ReadReplicas = append(matched.Config.ReadReplicas, searchReplicaSecrets(searchAppName, secrets.Items, matched.Config.Port)...)
+
 	// The creds given by app-interface have elevated privileges
 	matched.Config.AdminPassword = matched.Config.Password
 	matched.Config.AdminUsername = matched.Config.Username
@@ -229,6 +280,10 @@ func genDbConfigs(secrets []core.Secret, verify bool) ([]config.DatabaseConfigCo
 			},
 		}
 
+		if replica, ok := getReplicaConfig(secret, "db.replica.host", "db.replica.port", int(port)); ok {
+			dbConfig.Config.ReadReplicas = append(dbConfig.Config.ReadReplicas, replica)
+		}
+
 		configs = append(configs, dbConfig)
 	}
 
@@ -252,3 +307,34 @@ func searchAnnotationSecret(appName string, secrets []core.Secret) ([]config.Dat
 	}
 	return []config.DatabaseConfigContainer{}, nil
 }
+
+// getReplicaConfig reads a read replica from the given keys of the secret. The
+// port is optional and defaults to the port of the database.
+func getReplicaConfig(secret *core.Secret, hostKey, portKey string, defaultPort int) (config.DatabaseReplicaConfig, bool) {
+	host := string(secret.Data[hostKey])
+	if host == "" {
+		return config.DatabaseReplicaConfig{}, false
+	}
+
+	port := defaultPort
+	if p, err := strconv.ParseUint(string(secret.Data[portKey]), 10, 16); err == nil {
+		port = int(p)
+	}
+
+	return config.DatabaseReplicaConfig{Hostname: host, Port: port}, true
+}
+
+// searchReplicaSecrets returns the read replicas held by the secrets annotated
+// with clowder/database-replica for the given app.
+func searchReplicaSecrets(appName string, secrets []core.Secret, defaultPort int) []config.DatabaseReplicaConfig {
+	replicas := []config.DatabaseReplicaConfig{}
+	for i := range secrets {
+		if v, ok := secrets[i].GetAnnotations()["clowder/database-replica"]; !ok || v != appName {
+			continue
+		}
+		if replica, ok := getReplicaConfig(&secrets[i], "db.host", "db.port", defaultPort); ok {
+			replicas = append(replicas, replica)
+		}
+	}
+	return replicas
+}
@@ -5,8 +5,10 @@ import (
 	"testing"
 
 	crd "github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1alpha1"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/config"
 	"github.com/stretchr/testify/assert"
 	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestAppInterfaceDb(t *testing.T) {
@@ -32,3 +34,41 @@ func TestAppInterfaceDb(t *testing.T) {
 
 	assert.Equal(t, configs[0], resolved, "resolveDb did not match given config")
 }
+
+func TestAppInterfaceDbReplicas(t *testing.T) {
+	dbName := "test-db"
+	secrets := []core.Secret{{
+		Data: map[string][]byte{
+			"db.host":         []byte(fmt.Sprintf("%s-prod.amazing.aws.amazon.com", dbName)),
+			"db.port":         []byte("5432"),
+			"db.user":         []byte("user"),
+			"db.password":     []byte("password"),
+			"db.name":         []byte(dbName),
+			"db.replica.host": []byte(fmt.Sprintf("%s-replica-prod.amazing.aws.amazon.com", dbName)),
+		},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "replica",
+			Annotations: map[string]string{"clowder/database-replica": "app"},
+		},
+		Data: map[string][]byte{
+			"db.host": []byte("other-replica.amazing.aws.amazon.com"),
+			"db.port": []byte("5433"),
+		},
+	}}
+
+	configs, err := genDbConfigs(secrets, false)
+
+	assert.NoError(t, err, "failed to gen db config")
+	assert.Equal(t, 1, len(configs), "wrong number of configs")
+	assert.Equal(t, []config.DatabaseReplicaConfig{{
+		Hostname: fmt.Sprintf("%s-replica-prod.amazing.aws.amazon.com", dbName),
+		Port:     5432,
+	}}, configs[0].Config.ReadReplicas)
+
+	replicas := searchReplicaSecrets("app", secrets, 5432)
+	assert.Equal(t, []config.DatabaseReplicaConfig{{
+		Hostname: "other-replica.amazing.aws.amazon.com",
+		Port:     5433,
+	}}, replicas)
+}
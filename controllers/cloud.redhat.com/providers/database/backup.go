@@ -0,0 +1,374 @@
+package database
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/errors"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/object"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/sizing"
+	provutils "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/utils"
+
+	batch "k8s.io/api/batch/v1"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	rc "github.com/RedHatInsights/rhc-osdk-utils/resourceCache"
+	"github.com/RedHatInsights/rhc-osdk-utils/utils"
+)
+
+// DefaultBackupRetention is the number of backups kept when no retention is set.
+var DefaultBackupRetention int32 = 7
+
+// RestoreAnnotation names the backup to restore into the database instances of
+// a ClowdApp in local mode, or of a ClowdEnvironment in shared mode.
+const RestoreAnnotation = "clowder/database-restore"
+
+// DatabaseBackupCronJob is the ident referring to the cronjob backing up a database instance.
+var DatabaseBackupCronJob = rc.NewMultiResourceIdent(ProvName, "database_backup_cronjob", &batch.CronJob{})
+
+// DatabaseBackupPVC is the ident referring to the PVC holding the backups of a database instance.
+var DatabaseBackupPVC = rc.NewMultiResourceIdent(ProvName, "database_backup_pvc", &core.PersistentVolumeClaim{})
+
+// DatabaseBackupSecret is the ident referring to the object store credentials used by the backup
+// and restore jobs.
+var DatabaseBackupSecret = rc.NewMultiResourceIdent(ProvName, "database_backup_secret", &core.Secret{})
+
+// DatabaseRestoreJob is the ident referring to the job restoring a backup into a database instance.
+var DatabaseRestoreJob = rc.NewMultiResourceIdent(ProvName, "database_restore_job", &batch.Job{})
+
+// Every database of the instance, apart from the postgres database, is dumped
+// into a directory named after the time of the backup. The directory is only
+// given its name once every dump has succeeded, what is left of failed
+// backups is removed by the next run.
+const backupScript = `set -e
+until pg_isready -q; do echo "waiting for database"; sleep 2; done
+name="$(date -u +%Y%m%d%H%M%S)"
+rm -rf /backup/*.partial
+mkdir -p "/backup/${name}.partial"
+for db in $(psql -d postgres -tA -c "SELECT datname FROM pg_database WHERE NOT datistemplate AND datname <> 'postgres'"); do
+  pg_dump -Fc -d "${db}" -f "/backup/${name}.partial/${db}.dump"
+done
+mv "/backup/${name}.partial" "/backup/${name}"
+echo "created backup ${name}"
+`
+
+const prunePVCScript = `ls -1 /backup | grep -E '^[0-9]{14}$' | sort -r | tail -n +$((BACKUP_RETENTION + 1)) | while read -r old; do
+  echo "removing backup ${old}"
+  rm -rf "/backup/${old}"
+done
+`
+
+const uploadScript = `set -e
+mc --config-dir /backup/.mc alias set backup "http://${MINIO_HOSTNAME}:${MINIO_PORT}" "${MINIO_ACCESS_KEY}" "${MINIO_SECRET_KEY}"
+mc --config-dir /backup/.mc mb --ignore-existing "backup/${BACKUP_BUCKET}"
+name="$(ls -1 /backup)"
+mc --config-dir /backup/.mc cp --recursive "/backup/${name}/" "backup/${BACKUP_BUCKET}/${BACKUP_PREFIX}/${name}/"
+mc --config-dir /backup/.mc ls "backup/${BACKUP_BUCKET}/${BACKUP_PREFIX}/" | awk '{print $NF}' | sort -r | tail -n +$((BACKUP_RETENTION + 1)) | while read -r old; do
+  echo "removing backup ${old}"
+  mc --config-dir /backup/.mc rm --recursive --force "backup/${BACKUP_BUCKET}/${BACKUP_PREFIX}/${old}"
+done
+`
+
+const downloadScript = `set -e
+mc --config-dir /backup/.mc alias set backup "http://${MINIO_HOSTNAME}:${MINIO_PORT}" "${MINIO_ACCESS_KEY}" "${MINIO_SECRET_KEY}"
+mc --config-dir /backup/.mc cp --recursive "backup/${BACKUP_BUCKET}/${BACKUP_PREFIX}/${BACKUP_NAME}/" "/backup/${BACKUP_NAME}/"
+`
+
+// Databases which no longer exist are created before their dump is restored,
+// the objects of existing databases are replaced by those in the dump.
+const restoreScript = `set -e
+until pg_isready -q; do echo "waiting for database"; sleep 2; done
+if [ ! -d "/backup/${BACKUP_NAME}" ]; then
+  echo "backup ${BACKUP_NAME} not found"
+  exit 1
+fi
+for dump in "/backup/${BACKUP_NAME}"/*.dump; do
+  db="$(basename "${dump}" .dump)"
+  if [ "$(psql -d postgres -tA -c "SELECT 1 FROM pg_database WHERE datname = '${db}'")" != "1" ]; then
+    createdb "${db}"
+  fi
+  pg_restore --clean --if-exists --single-transaction -d "${db}" "${dump}"
+done
+echo "restored backup ${BACKUP_NAME}"
+`
+
+// databaseInstance describes a database deployment created by Clowder, which is
+// owned by a ClowdApp in local mode, and by the ClowdEnvironment in shared mode.
+type databaseInstance struct {
+	owner object.ClowdObject
+	// The namespaced name of the database deployment and its service
+	nn types.NamespacedName
+	// The secret holding the hostname, port and admin password of the instance
+	secretName string
+	image      string
+	volumeSize string
+}
+
+func (i *databaseInstance) suffixed(suffix string) types.NamespacedName {
+	return types.NamespacedName{
+		Name:      fmt.Sprintf("%s-%s", i.nn.Name, suffix),
+		Namespace: i.nn.Namespace,
+	}
+}
+
+func (i *databaseInstance) setObjectMeta(o metav1.Object, nn types.NamespacedName) {
+	labels := i.owner.GetLabels()
+	labels["service"] = "db-backup"
+	o.SetName(nn.Name)
+	o.SetNamespace(nn.Namespace)
+	o.SetLabels(labels)
+	o.SetOwnerReferences([]metav1.OwnerReference{i.owner.MakeOwnerReference()})
+}
+
+// GetRestoreJobName returns the name of the job restoring the named backup into
+// the database deployment with the given name.
+func GetRestoreJobName(deploymentName string, backupName string) string {
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(backupName)))
+	return fmt.Sprintf("%s-restore-%s", deploymentName, hash[:8])
+}
+
+// makeBackup creates the cronjob backing up a database instance, along with
+// the storage of its target, and a restore job when one is requested.
+func makeBackup(p *providers.Provider, instance *databaseInstance) error {
+	backup := p.Env.Spec.Providers.Database.Backup
+	if backup == nil {
+		return nil
+	}
+
+	if err := makeBackupStorage(p, instance); err != nil {
+		return err
+	}
+
+	nn := instance.suffixed("backup")
+
+	cj := &batch.CronJob{}
+	if err := p.Cache.Create(DatabaseBackupCronJob, nn, cj); err != nil {
+		return err
+	}
+
+	instance.setObjectMeta(cj, nn)
+	utils.UpdateAnnotations(cj, provutils.KubeLinterAnnotations)
+
+	retention := backup.Retention
+	if retention == 0 {
+		retention = DefaultBackupRetention
+	}
+
+	dump := makeDatabaseContainer(p, instance, "backup", backupScript)
+
+	var containers, initContainers []core.Container
+	volume := core.Volume{Name: "backup"}
+
+	if backup.Target.PVC != nil {
+		volume.VolumeSource = core.VolumeSource{
+			PersistentVolumeClaim: &core.PersistentVolumeClaimVolumeSource{ClaimName: nn.Name},
+		}
+		dump.Command[2] = backupScript + prunePVCScript
+		dump.Env = append(dump.Env, core.EnvVar{Name: "BACKUP_RETENTION", Value: fmt.Sprintf("%d", retention)})
+		containers = []core.Container{dump}
+	} else {
+		volume.VolumeSource = core.VolumeSource{EmptyDir: &core.EmptyDirVolumeSource{}}
+		upload := makeObjectStoreContainer(p, instance, "upload", uploadScript)
+		upload.Env = append(upload.Env, core.EnvVar{Name: "BACKUP_RETENTION", Value: fmt.Sprintf("%d", retention)})
+		initContainers = []core.Container{dump}
+		containers = []core.Container{upload}
+	}
+
+	cj.Spec.Schedule = backup.Schedule
+	cj.Spec.ConcurrencyPolicy = batch.ForbidConcurrent
+	cj.Spec.SuccessfulJobsHistoryLimit = utils.Int32Ptr(1)
+	cj.Spec.FailedJobsHistoryLimit = utils.Int32Ptr(1)
+	cj.Spec.JobTemplate.Spec.BackoffLimit = utils.Int32Ptr(2)
+
+	template := &cj.Spec.JobTemplate.Spec.Template
+	template.ObjectMeta.Labels = cj.GetLabels()
+	utils.UpdateAnnotations(template, provutils.KubeLinterAnnotations)
+	template.Spec = makeDatabaseJobPodSpec(instance, initContainers, containers, volume)
+
+	if err := p.Cache.Update(DatabaseBackupCronJob, cj); err != nil {
+		return err
+	}
+
+	return makeRestoreJob(p, instance)
+}
+
+// makeBackupStorage creates the PVC or object store credentials used by the backup and restore
+// jobs of a database instance.
+func makeBackupStorage(p *providers.Provider, instance *databaseInstance) error {
+	target := p.Env.Spec.Providers.Database.Backup.Target
+	nn := instance.suffixed("backup")
+
+	switch {
+	case target.PVC != nil:
+		volumeSize := target.PVC.VolumeSize
+		if volumeSize == "" {
+			volumeSize = instance.volumeSize
+		}
+
+		pvc := &core.PersistentVolumeClaim{}
+		if err := p.Cache.Create(DatabaseBackupPVC, nn, pvc); err != nil {
+			return err
+		}
+
+		// The size of an existing claim can not be reduced
+		if pvc.GetUID() == "" {
+			utils.MakePVC(pvc, nn, map[string]string{"service": "db-backup"}, sizing.GetVolCapacityForSize(volumeSize), instance.owner)
+		}
+
+		return p.Cache.Update(DatabaseBackupPVC, pvc)
+	case target.ObjectStore != nil:
+		return makeMinioSecret(p, instance.owner, DatabaseBackupSecret, nn, "Database backup")
+	default:
+		return errors.NewClowderError("No database backup target set")
+	}
+}
+
+// makeRestoreJob creates a job restoring the backup named in the restore annotation of the owner
+// of the database instance. The job is named after the backup, so every backup is only restored
+// once.
+func makeRestoreJob(p *providers.Provider, instance *databaseInstance) error {
+	backupName := instance.owner.GetAnnotations()[RestoreAnnotation]
+	if backupName == "" {
+		return nil
+	}
+
+	nn := types.NamespacedName{
+		Name:      GetRestoreJobName(instance.nn.Name, backupName),
+		Namespace: instance.nn.Namespace,
+	}
+
+	job := &batch.Job{}
+	if err := p.Cache.Create(DatabaseRestoreJob, nn, job); err != nil {
+		return err
+	}
+
+	// The pod template of a job is immutable and a different backup gives a
+	// new job name, so an existing job is left as it is.
+	if job.GetUID() != "" {
+		return p.Cache.Update(DatabaseRestoreJob, job)
+	}
+
+	instance.setObjectMeta(job, nn)
+	utils.UpdateAnnotations(job, provutils.KubeLinterAnnotations)
+
+	restore := makeDatabaseContainer(p, instance, "restore", restoreScript)
+	restore.Env = append(restore.Env, core.EnvVar{Name: "BACKUP_NAME", Value: backupName})
+
+	var initContainers []core.Container
+	volume := core.Volume{Name: "backup"}
+
+	if p.Env.Spec.Providers.Database.Backup.Target.PVC != nil {
+		volume.VolumeSource = core.VolumeSource{
+			PersistentVolumeClaim: &core.PersistentVolumeClaimVolumeSource{
+				ClaimName: instance.suffixed("backup").Name,
+				ReadOnly:  true,
+			},
+		}
+		restore.VolumeMounts[0].ReadOnly = true
+	} else {
+		volume.VolumeSource = core.VolumeSource{EmptyDir: &core.EmptyDirVolumeSource{}}
+		download := makeObjectStoreContainer(p, instance, "download", downloadScript)
+		download.Env = append(download.Env, core.EnvVar{Name: "BACKUP_NAME", Value: backupName})
+		initContainers = []core.Container{download}
+	}
+
+	job.Spec.BackoffLimit = utils.Int32Ptr(2)
+	job.Spec.Template.ObjectMeta.Labels = job.GetLabels()
+	utils.UpdateAnnotations(&job.Spec.Template, provutils.KubeLinterAnnotations)
+	job.Spec.Template.Spec = makeDatabaseJobPodSpec(instance, initContainers, []core.Container{restore}, volume)
+
+	return p.Cache.Update(DatabaseRestoreJob, job)
+}
+
+func makeDatabaseJobPodSpec(instance *databaseInstance, initContainers []core.Container, containers []core.Container, volume core.Volume) core.PodSpec {
+	return core.PodSpec{
+		InitContainers:                initContainers,
+		Containers:                    containers,
+		Volumes:                       []core.Volume{volume},
+		RestartPolicy:                 core.RestartPolicyNever,
+		ServiceAccountName:            instance.owner.GetClowdSAName(),
+		TerminationGracePeriodSeconds: utils.Int64Ptr(30),
+		SecurityContext:               &core.PodSecurityContext{},
+		SchedulerName:                 "default-scheduler",
+		DNSPolicy:                     core.DNSClusterFirst,
+	}
+}
+
+// makeDatabaseContainer returns a container running the script against the database instance as
+// the postgres user.
+func makeDatabaseContainer(p *providers.Provider, instance *databaseInstance, name string, script string) core.Container {
+	secretEnv := func(name string, key string) core.EnvVar {
+		return core.EnvVar{
+			Name: name,
+			ValueFrom: &core.EnvVarSource{
+				SecretKeyRef: &core.SecretKeySelector{
+					LocalObjectReference: core.LocalObjectReference{Name: instance.secretName},
+					Key:                  key,
+				},
+			},
+		}
+	}
+
+	return core.Container{
+		Name:    name,
+		Image:   instance.image,
+		Command: []string{"/bin/bash", "-c", script},
+		Env: []core.EnvVar{
+			secretEnv("PGHOST", "hostname"),
+			secretEnv("PGPORT", "port"),
+			secretEnv("PGPASSWORD", "pgPass"),
+			{Name: "PGUSER", Value: "postgres"},
+		},
+		Resources: p.Env.Spec.ResourceDefaults,
+		VolumeMounts: []core.VolumeMount{{
+			Name:      "backup",
+			MountPath: "/backup",
+		}},
+		TerminationMessagePath:   "/dev/termination-log",
+		TerminationMessagePolicy: core.TerminationMessageReadFile,
+		ImagePullPolicy:          core.PullIfNotPresent,
+	}
+}
+
+// makeObjectStoreContainer returns a container running the script with the MinIO client against
+// the backup bucket of the environment. The backups of each instance are kept under a prefix
+// named after its namespace and deployment.
+func makeObjectStoreContainer(p *providers.Provider, instance *databaseInstance, name string, script string) core.Container {
+	secretName := instance.suffixed("backup").Name
+	secretEnv := func(name string, key string) core.EnvVar {
+		return core.EnvVar{
+			Name: name,
+			ValueFrom: &core.EnvVarSource{
+				SecretKeyRef: &core.SecretKeySelector{
+					LocalObjectReference: core.LocalObjectReference{Name: secretName},
+					Key:                  key,
+				},
+			},
+		}
+	}
+
+	return core.Container{
+		Name:    name,
+		Image:   getMinioClientImage(),
+		Command: []string{"/bin/sh", "-c", script},
+		Env: []core.EnvVar{
+			secretEnv("MINIO_HOSTNAME", "hostname"),
+			secretEnv("MINIO_PORT", "port"),
+			secretEnv("MINIO_ACCESS_KEY", "accessKey"),
+			secretEnv("MINIO_SECRET_KEY", "secretKey"),
+			{Name: "BACKUP_BUCKET", Value: p.Env.Spec.Providers.Database.Backup.Target.ObjectStore.Bucket},
+			{Name: "BACKUP_PREFIX", Value: fmt.Sprintf("%s/%s", instance.nn.Namespace, instance.nn.Name)},
+		},
+		Resources: p.Env.Spec.ResourceDefaults,
+		VolumeMounts: []core.VolumeMount{{
+			Name:      "backup",
+			MountPath: "/backup",
+		}},
+		TerminationMessagePath:   "/dev/termination-log",
+		TerminationMessagePolicy: core.TerminationMessageReadFile,
+		ImagePullPolicy:          core.PullIfNotPresent,
+	}
+}
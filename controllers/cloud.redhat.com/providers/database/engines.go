@@ -0,0 +1,304 @@
+package database
+
+import (
+	"fmt"
+
+	crd "github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1alpha1"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/clowderconfig"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/config"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/errors"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/sizing"
+	provutils "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/utils"
+
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/RedHatInsights/rhc-osdk-utils/utils"
+)
+
+const (
+	// EnginePostgreSQL is the default database engine.
+	EnginePostgreSQL = "postgresql"
+	// EngineMySQL is the MySQL database engine.
+	EngineMySQL = "mysql"
+	// EngineMongoDB is the MongoDB database engine.
+	EngineMongoDB = "mongodb"
+)
+
+var DefaultImageDatabaseMySQL = "quay.io/sclorg/mysql-80-c9s:c9s"
+var DefaultImageDatabaseMongoDB = "docker.io/bitnami/mongodb:6.0"
+
+// localEngine describes how a database engine other than postgresql is run in
+// local mode.
+type localEngine struct {
+	port          int32
+	adminUsername string
+	dataPath      string
+	image         func() string
+	env           func(cfg *config.DatabaseConfig) []core.EnvVar
+	probe         []string
+}
+
+var localEngines = map[string]*localEngine{
+	EngineMySQL: {
+		port:          3306,
+		adminUsername: "root",
+		dataPath:      "/var/lib/mysql/data",
+		image: func() string {
+			if clowderconfig.LoadedConfig.Images.MySQL != "" {
+				return clowderconfig.LoadedConfig.Images.MySQL
+			}
+			return DefaultImageDatabaseMySQL
+		},
+		env: func(cfg *config.DatabaseConfig) []core.EnvVar {
+			return []core.EnvVar{
+				{Name: "MYSQL_USER", Value: cfg.Username},
+				{Name: "MYSQL_PASSWORD", Value: cfg.Password},
+				{Name: "MYSQL_ROOT_PASSWORD", Value: cfg.AdminPassword},
+				{Name: "MYSQL_DATABASE", Value: cfg.Name},
+			}
+		},
+		probe: []string{"/bin/sh", "-c", `MYSQL_PWD="${MYSQL_PASSWORD}" mysql -h 127.0.0.1 -u "${MYSQL_USER}" -D "${MYSQL_DATABASE}" -e 'SELECT 1'`},
+	},
+	EngineMongoDB: {
+		port:          27017,
+		adminUsername: "root",
+		dataPath:      "/bitnami/mongodb",
+		image: func() string {
+			if clowderconfig.LoadedConfig.Images.MongoDB != "" {
+				return clowderconfig.LoadedConfig.Images.MongoDB
+			}
+			return DefaultImageDatabaseMongoDB
+		},
+		env: func(cfg *config.DatabaseConfig) []core.EnvVar {
+			return []core.EnvVar{
+				{Name: "MONGODB_USERNAME", Value: cfg.Username},
+				{Name: "MONGODB_PASSWORD", Value: cfg.Password},
+				{Name: "MONGODB_ROOT_USER", Value: cfg.AdminUsername},
+				{Name: "MONGODB_ROOT_PASSWORD", Value: cfg.AdminPassword},
+				{Name: "MONGODB_DATABASE", Value: cfg.Name},
+			}
+		},
+		probe: []string{"/bin/sh", "-c", `mongosh --quiet --eval "db.adminCommand('ping')"`},
+	},
+}
+
+// getEngine returns the database engine requested by the app.
+func getEngine(app *crd.ClowdApp) string {
+	if app.Spec.Database.Engine == "" {
+		return EnginePostgreSQL
+	}
+	return app.Spec.Database.Engine
+}
+
+// setEngineConfig places the database config in the section of the app config
+// matching the engine.
+func setEngineConfig(c *config.AppConfig, engine string, dbCfg *config.DatabaseConfig) {
+	switch engine {
+	case EngineMySQL:
+		c.Mysql = &config.MySQLConfig{
+			Hostname:      dbCfg.Hostname,
+			Port:          dbCfg.Port,
+			Name:          dbCfg.Name,
+			Username:      dbCfg.Username,
+			Password:      dbCfg.Password,
+			AdminUsername: dbCfg.AdminUsername,
+			AdminPassword: dbCfg.AdminPassword,
+			RdsCa:         dbCfg.RdsCa,
+		}
+	case EngineMongoDB:
+		c.Mongodb = &config.MongoDBConfig{
+			Hostname:      dbCfg.Hostname,
+			Port:          dbCfg.Port,
+			Name:          dbCfg.Name,
+			Username:      dbCfg.Username,
+			Password:      dbCfg.Password,
+			AdminUsername: dbCfg.AdminUsername,
+			AdminPassword: dbCfg.AdminPassword,
+			RdsCa:         dbCfg.RdsCa,
+		}
+	default:
+		c.Database = dbCfg
+	}
+}
+
+// provideLocalEngine runs a database of an engine other than postgresql for the
+// app, in the same way as the local postgresql database.
+func (db *localDbProvider) provideLocalEngine(app *crd.ClowdApp, engineName string) error {
+	engine, ok := localEngines[engineName]
+	if !ok {
+		return errors.NewClowderError(fmt.Sprintf("Requested database engine (%s), doesn't exist", engineName))
+	}
+
+	nn := types.NamespacedName{
+		Name:      fmt.Sprintf("%v-db", app.Name),
+		Namespace: app.Namespace,
+	}
+
+	dd := &apps.Deployment{}
+	if err := db.Cache.Create(LocalDBDeployment, nn, dd); err != nil {
+		return err
+	}
+
+	password, err := utils.RandPassword(16, provutils.RCharSet)
+	if err != nil {
+		return errors.Wrap("password generate failed", err)
+	}
+
+	adminPassword, err := utils.RandPassword(16, provutils.RCharSet)
+	if err != nil {
+		return errors.Wrap("adminPassword generate failed", err)
+	}
+
+	dataInit := func() map[string]string {
+		hostname := fmt.Sprintf("%v.%v.svc", nn.Name, nn.Namespace)
+		port := fmt.Sprintf("%d", engine.port)
+		username := utils.RandString(16)
+		name := app.Spec.Database.Name
+
+		return map[string]string{
+			"hostname":    hostname,
+			"db.host":     hostname,
+			"port":        port,
+			"db.port":     port,
+			"username":    username,
+			"db.user":     username,
+			"password":    password,
+			"db.password": password,
+			"pgPass":      adminPassword,
+			"name":        name,
+			"db.name":     name,
+		}
+	}
+
+	secMap, err := providers.MakeOrGetSecret(app, db.Cache, LocalDBSecret, nn, dataInit)
+	if err != nil {
+		return errors.Wrap("Couldn't set/get secret", err)
+	}
+
+	dbCfg := config.DatabaseConfig{}
+	if err := dbCfg.Populate(secMap); err != nil {
+		return errors.Wrap("couldn't convert to int", err)
+	}
+	dbCfg.AdminUsername = engine.adminUsername
+
+	resources := sizing.GetResourceRequirementsForSize(app.Spec.Database.DBResourceSize)
+	usePVC := db.Env.Spec.Providers.Database.PVC
+	makeLocalEngineDB(dd, nn, app, engine, &dbCfg, usePVC, &resources)
+
+	if err := db.Cache.Update(LocalDBDeployment, dd); err != nil {
+		return err
+	}
+
+	s := &core.Service{}
+	if err := db.Cache.Create(LocalDBService, nn, s); err != nil {
+		return err
+	}
+
+	servicePorts := []core.ServicePort{{
+		Name:       "database",
+		Port:       engine.port,
+		Protocol:   core.ProtocolTCP,
+		TargetPort: intstr.FromInt(int(engine.port)),
+	}}
+	labels := providers.Labels{"service": "db", "app": app.GetClowdName(), "sub": "local_db"}
+	utils.MakeService(s, nn, labels, servicePorts, app, false)
+
+	if err := db.Cache.Update(LocalDBService, s); err != nil {
+		return err
+	}
+
+	if usePVC {
+		pvc := &core.PersistentVolumeClaim{}
+		if err := db.Cache.Create(LocalDBPVC, nn, pvc); err != nil {
+			return err
+		}
+
+		provutils.MakeLocalDBPVC(pvc, nn, app, sizing.GetVolCapacityForSize(app.Spec.Database.DBVolumeSize))
+
+		if err := db.Cache.Update(LocalDBPVC, pvc); err != nil {
+			return err
+		}
+	}
+
+	setEngineConfig(db.Config, engineName, &dbCfg)
+	return nil
+}
+
+// makeLocalEngineDB populates the given deployment with a single node database
+// of the engine.
+func makeLocalEngineDB(dd *apps.Deployment, nn types.NamespacedName, app *crd.ClowdApp, engine *localEngine, cfg *config.DatabaseConfig, usePVC bool, res *core.ResourceRequirements) {
+	labels := app.GetLabels()
+	labels["service"] = "db"
+	labels["sub"] = "local_db"
+
+	utils.MakeLabeler(nn, labels, app)(dd)
+
+	volSource := core.VolumeSource{
+		EmptyDir: &core.EmptyDirVolumeSource{},
+	}
+	if usePVC {
+		volSource = core.VolumeSource{
+			PersistentVolumeClaim: &core.PersistentVolumeClaimVolumeSource{
+				ClaimName: nn.Name,
+			},
+		}
+	}
+
+	// Two copies of the server must never share the same data
+	dd.Spec.Strategy.Type = apps.RecreateDeploymentStrategyType
+	dd.Spec.Strategy.RollingUpdate = nil
+
+	dd.Spec.Replicas = utils.Int32Ptr(1)
+	dd.Spec.Selector = &metav1.LabelSelector{MatchLabels: labels}
+	dd.Spec.Template.ObjectMeta.Labels = labels
+	dd.Spec.Template.Spec.Volumes = []core.Volume{{
+		Name:         nn.Name,
+		VolumeSource: volSource,
+	}}
+
+	probeHandler := core.ProbeHandler{
+		Exec: &core.ExecAction{
+			Command: engine.probe,
+		},
+	}
+
+	dd.Spec.Template.Spec.Containers = []core.Container{{
+		Name:  nn.Name,
+		Image: engine.image(),
+		Env:   engine.env(cfg),
+		Ports: []core.ContainerPort{{
+			Name:          "database",
+			ContainerPort: engine.port,
+			Protocol:      core.ProtocolTCP,
+		}},
+		LivenessProbe: &core.Probe{
+			ProbeHandler:        probeHandler,
+			InitialDelaySeconds: 15,
+			TimeoutSeconds:      5,
+			PeriodSeconds:       10,
+			SuccessThreshold:    1,
+			FailureThreshold:    3,
+		},
+		ReadinessProbe: &core.Probe{
+			ProbeHandler:        probeHandler,
+			InitialDelaySeconds: 20,
+			TimeoutSeconds:      5,
+			PeriodSeconds:       10,
+			SuccessThreshold:    1,
+			FailureThreshold:    3,
+		},
+		Resources: *res,
+		VolumeMounts: []core.VolumeMount{{
+			Name:      nn.Name,
+			MountPath: engine.dataPath,
+		}},
+		TerminationMessagePath:   "/dev/termination-log",
+		TerminationMessagePolicy: core.TerminationMessageReadFile,
+		ImagePullPolicy:          core.PullIfNotPresent,
+	}}
+}
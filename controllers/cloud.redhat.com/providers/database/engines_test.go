@@ -0,0 +1,41 @@
+package database
+
+import (
+	"testing"
+
+	crd "github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1alpha1"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEngineConfig(t *testing.T) {
+	app := &crd.ClowdApp{}
+	assert.Equal(t, EnginePostgreSQL, getEngine(app))
+
+	dbCfg := &config.DatabaseConfig{
+		Hostname:      "app-db.ns.svc",
+		Port:          3306,
+		Name:          "app",
+		Username:      "user",
+		Password:      "password",
+		AdminUsername: "root",
+		AdminPassword: "admin",
+	}
+
+	c := &config.AppConfig{}
+	setEngineConfig(c, EngineMySQL, dbCfg)
+	assert.Nil(t, c.Database)
+	assert.Nil(t, c.Mongodb)
+	assert.Equal(t, "app-db.ns.svc", c.Mysql.Hostname)
+	assert.Equal(t, 3306, c.Mysql.Port)
+	assert.Equal(t, "root", c.Mysql.AdminUsername)
+
+	c = &config.AppConfig{}
+	setEngineConfig(c, EngineMongoDB, dbCfg)
+	assert.Nil(t, c.Mysql)
+	assert.Equal(t, "app", c.Mongodb.Name)
+
+	c = &config.AppConfig{}
+	setEngineConfig(c, EnginePostgreSQL, dbCfg)
+	assert.Equal(t, dbCfg, c.Database)
+}
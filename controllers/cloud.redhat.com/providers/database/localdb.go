@@ -42,6 +42,17 @@ func NewLocalDBProvider(p *providers.Provider) (providers.ClowderProvider, error
 		LocalDBService,
 		LocalDBPVC,
 		LocalDBSecret,
+		DatabaseSeedJob,
+		DatabaseSeedSecret,
+		DatabaseBackupCronJob,
+		DatabaseBackupPVC,
+		DatabaseBackupSecret,
+		DatabaseRestoreJob,
+		DatabasePoolerDeployment,
+		DatabasePoolerService,
+		DatabasePoolerSecret,
+		LocalDBReplicaDeployment,
+		LocalDBReplicaService,
 	)
 	return &localDbProvider{Provider: *p}, nil
 }
@@ -61,6 +72,10 @@ func (db *localDbProvider) Provide(app *crd.ClowdApp) error {
 		return db.processSharedDB(app)
 	}
 
+	if engine := getEngine(app); engine != EnginePostgreSQL {
+		return db.provideLocalEngine(app, engine)
+	}
+
 	nn := types.NamespacedName{
 		Name:      fmt.Sprintf("%v-db", app.Name),
 		Namespace: app.Namespace,
@@ -121,7 +136,7 @@ func (db *localDbProvider) Provide(app *crd.ClowdApp) error {
 
 	var image string
 
-	var dbVersion int32 = 12
+	dbVersion := crd.DefaultDatabaseVersion
 	if app.Spec.Database.Version != nil {
 		dbVersion = *(app.Spec.Database.Version)
 	}
@@ -172,6 +187,30 @@ func (db *localDbProvider) Provide(app *crd.ClowdApp) error {
 			return err
 		}
 	}
+
+	instance := &databaseInstance{
+		owner:      app,
+		nn:         nn,
+		secretName: nn.Name,
+		image:      image,
+		volumeSize: app.Spec.Database.DBVolumeSize,
+	}
+	if err := makeBackup(&db.Provider, instance); err != nil {
+		return err
+	}
+
+	if err := makeSeedJob(&db.Provider, app, imageList[dbVersion]); err != nil {
+		return err
+	}
+
+	if err := makeLocalReplicas(&db.Provider, app, nn, &dbCfg, image); err != nil {
+		return err
+	}
+
+	if err := makePooler(&db.Provider, app, &dbCfg); err != nil {
+		return err
+	}
+
 	db.Config.Database = &dbCfg
 	return nil
 }
@@ -215,8 +254,18 @@ func (db *localDbProvider) processSharedDB(app *crd.ClowdApp) error {
 	if err != nil {
 		return errors.Wrap("couldn't convert to int", err)
 	}
+	engine := getEngine(refApp)
+	if localEngine, ok := localEngines[engine]; ok {
+		dbCfg.AdminUsername = localEngine.adminUsername
+		setEngineConfig(db.Config, engine, &dbCfg)
+		return nil
+	}
+
 	dbCfg.AdminUsername = "postgres"
 
+	useLocalReplicas(&dbCfg, refApp)
+	usePooler(&dbCfg, db.Env, refApp)
+
 	db.Config.Database = &dbCfg
 
 	return nil
@@ -0,0 +1,275 @@
+package database
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strconv"
+	"strings"
+
+	crd "github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1alpha1"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/clowderconfig"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/config"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/sizing"
+
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	rc "github.com/RedHatInsights/rhc-osdk-utils/resourceCache"
+	"github.com/RedHatInsights/rhc-osdk-utils/utils"
+)
+
+var DefaultImageDatabasePooler = "docker.io/edoburu/pgbouncer:1.18.0"
+
+// DefaultPoolerMode is the pool mode used when none is set.
+var DefaultPoolerMode = "transaction"
+
+// DefaultPoolerPoolSize is the number of server connections per user and database used when none is set.
+var DefaultPoolerPoolSize int32 = 20
+
+// DefaultPoolerMaxClientConnections is the number of client connections allowed when none is set.
+var DefaultPoolerMaxClientConnections int32 = 100
+
+// PoolerPort is the port the pooler listens on.
+const PoolerPort = 6432
+
+// PoolerAnnotation marks the secret holding the pooler of an app in app-interface mode.
+const PoolerAnnotation = "clowder/database-pooler"
+
+// DatabasePoolerDeployment is the ident referring to the pooler deployment object.
+var DatabasePoolerDeployment = rc.NewSingleResourceIdent(ProvName, "database_pooler_deployment", &apps.Deployment{})
+
+// DatabasePoolerService is the ident referring to the pooler service object.
+var DatabasePoolerService = rc.NewSingleResourceIdent(ProvName, "database_pooler_service", &core.Service{})
+
+// DatabasePoolerSecret is the ident referring to the secret holding the pooler configuration.
+var DatabasePoolerSecret = rc.NewSingleResourceIdent(ProvName, "database_pooler_secret", &core.Secret{})
+
+func getPoolerImage() string {
+	if clowderconfig.LoadedConfig.Images.PgBouncer != "" {
+		return clowderconfig.LoadedConfig.Images.PgBouncer
+	}
+	return DefaultImageDatabasePooler
+}
+
+// getPoolerSpec returns the pooler settings of the app laid over those of the
+// environment, with defaults filled in, or nil when neither asks for a pooler.
+func getPoolerSpec(env *crd.ClowdEnvironment, app *crd.ClowdApp) *crd.DatabasePooler {
+	envPooler := env.Spec.Providers.Database.Pooler
+	appPooler := app.Spec.Database.Pooler
+
+	if envPooler == nil && appPooler == nil {
+		return nil
+	}
+
+	pooler := &crd.DatabasePooler{
+		Mode:                 DefaultPoolerMode,
+		PoolSize:             DefaultPoolerPoolSize,
+		MaxClientConnections: DefaultPoolerMaxClientConnections,
+	}
+
+	for _, p := range []*crd.DatabasePooler{envPooler, appPooler} {
+		if p == nil {
+			continue
+		}
+		if p.Mode != "" {
+			pooler.Mode = p.Mode
+		}
+		if p.PoolSize != 0 {
+			pooler.PoolSize = p.PoolSize
+		}
+		if p.MaxClientConnections != 0 {
+			pooler.MaxClientConnections = p.MaxClientConnections
+		}
+	}
+
+	return pooler
+}
+
+func getPoolerNamespacedName(app *crd.ClowdApp) types.NamespacedName {
+	return types.NamespacedName{
+		Name:      fmt.Sprintf("%s-db-pooler", app.Name),
+		Namespace: app.Namespace,
+	}
+}
+
+// usePooler points the config at the pooler of the given app, which is the app
+// sharing its database when sharedDbAppName is used.
+func usePooler(dbCfg *config.DatabaseConfig, env *crd.ClowdEnvironment, app *crd.ClowdApp) {
+	if getPoolerSpec(env, app) == nil {
+		return
+	}
+	nn := getPoolerNamespacedName(app)
+	dbCfg.Hostname = fmt.Sprintf("%s.%s.svc", nn.Name, nn.Namespace)
+	dbCfg.Port = PoolerPort
+}
+
+func quoteUserlist(s string) string {
+	return fmt.Sprintf(`"%s"`, strings.ReplaceAll(s, `"`, `""`))
+}
+
+// makePoolerConfig renders the pgbouncer.ini and userlist.txt of a pooler in
+// front of the database server described by the config. Every database on the
+// server is reachable through the pooler, by the app user and, when the app was
+// given them, the admin credentials.
+func makePoolerConfig(dbCfg *config.DatabaseConfig, pooler *crd.DatabasePooler) map[string]string {
+	ini := fmt.Sprintf(`[databases]
+* = host=%s port=%d
+
+[pgbouncer]
+listen_addr = 0.0.0.0
+listen_port = %d
+unix_socket_dir =
+auth_type = scram-sha-256
+auth_file = /etc/pgbouncer/userlist.txt
+pool_mode = %s
+default_pool_size = %d
+max_client_conn = %d
+ignore_startup_parameters = extra_float_digits
+`, dbCfg.Hostname, dbCfg.Port, PoolerPort, pooler.Mode, pooler.PoolSize, pooler.MaxClientConnections)
+
+	userlist := fmt.Sprintf("%s %s\n", quoteUserlist(dbCfg.Username), quoteUserlist(dbCfg.Password))
+	if dbCfg.AdminUsername != "" && dbCfg.AdminPassword != "" {
+		userlist += fmt.Sprintf("%s %s\n", quoteUserlist(dbCfg.AdminUsername), quoteUserlist(dbCfg.AdminPassword))
+	}
+
+	return map[string]string{
+		"pgbouncer.ini": ini,
+		"userlist.txt":  userlist,
+	}
+}
+
+// makePooler deploys a PgBouncer pooler in front of the database of the app,
+// and points the config at it. The pooler only exists while the app or the
+// environment asks for one.
+func makePooler(p *providers.Provider, app *crd.ClowdApp, dbCfg *config.DatabaseConfig) error {
+	pooler := getPoolerSpec(p.Env, app)
+	if pooler == nil {
+		return nil
+	}
+
+	nn := getPoolerNamespacedName(app)
+	labels := app.GetLabels()
+	labels["service"] = "db-pooler"
+
+	data := makePoolerConfig(dbCfg, pooler)
+
+	secret := &core.Secret{}
+	if err := p.Cache.Create(DatabasePoolerSecret, nn, secret); err != nil {
+		return err
+	}
+
+	utils.MakeLabeler(nn, labels, app)(secret)
+	secret.Type = core.SecretTypeOpaque
+	secret.Data = nil
+	secret.StringData = data
+
+	if err := p.Cache.Update(DatabasePoolerSecret, secret); err != nil {
+		return err
+	}
+
+	hash := sha256.Sum256([]byte(data["pgbouncer.ini"] + data["userlist.txt"]))
+
+	dd := &apps.Deployment{}
+	if err := p.Cache.Create(DatabasePoolerDeployment, nn, dd); err != nil {
+		return err
+	}
+
+	utils.MakeLabeler(nn, labels, app)(dd)
+
+	resources := sizing.GetResourceRequirementsForSize(app.Spec.Database.DBResourceSize)
+	probe := &core.Probe{
+		ProbeHandler: core.ProbeHandler{
+			TCPSocket: &core.TCPSocketAction{
+				Port: intstr.FromInt(PoolerPort),
+			},
+		},
+		InitialDelaySeconds: 5,
+		TimeoutSeconds:      2,
+		PeriodSeconds:       10,
+		SuccessThreshold:    1,
+		FailureThreshold:    3,
+	}
+
+	dd.Spec.Replicas = utils.Int32Ptr(1)
+	dd.Spec.Selector = &metav1.LabelSelector{MatchLabels: labels}
+	dd.Spec.Template.ObjectMeta.Labels = labels
+	dd.Spec.Template.ObjectMeta.Annotations = map[string]string{
+		"configHash": fmt.Sprintf("%x", hash),
+	}
+	dd.Spec.Template.Spec.Volumes = []core.Volume{{
+		Name: "config",
+		VolumeSource: core.VolumeSource{
+			Secret: &core.SecretVolumeSource{
+				SecretName: nn.Name,
+			},
+		},
+	}}
+	dd.Spec.Template.Spec.Containers = []core.Container{{
+		Name:    "pgbouncer",
+		Image:   getPoolerImage(),
+		Command: []string{"pgbouncer", "/etc/pgbouncer/pgbouncer.ini"},
+		Ports: []core.ContainerPort{{
+			Name:          "pooler",
+			ContainerPort: PoolerPort,
+			Protocol:      core.ProtocolTCP,
+		}},
+		VolumeMounts: []core.VolumeMount{{
+			Name:      "config",
+			MountPath: "/etc/pgbouncer",
+			ReadOnly:  true,
+		}},
+		LivenessProbe:            probe,
+		ReadinessProbe:           probe,
+		Resources:                resources,
+		TerminationMessagePath:   "/dev/termination-log",
+		TerminationMessagePolicy: core.TerminationMessageReadFile,
+		ImagePullPolicy:          core.PullIfNotPresent,
+	}}
+
+	if err := p.Cache.Update(DatabasePoolerDeployment, dd); err != nil {
+		return err
+	}
+
+	s := &core.Service{}
+	if err := p.Cache.Create(DatabasePoolerService, nn, s); err != nil {
+		return err
+	}
+
+	servicePorts := []core.ServicePort{{
+		Name:       "pooler",
+		Port:       PoolerPort,
+		Protocol:   core.ProtocolTCP,
+		TargetPort: intstr.FromInt(PoolerPort),
+	}}
+	utils.MakeService(s, nn, map[string]string{"service": "db-pooler", "app": app.Name}, servicePorts, app, false)
+
+	if err := p.Cache.Update(DatabasePoolerService, s); err != nil {
+		return err
+	}
+
+	usePooler(dbCfg, p.Env, app)
+	return nil
+}
+
+// getPoolerConfig overrides the hostname and port of the config with those of
+// the secret annotated with clowder/database-pooler for the given app. It is
+// used in app-interface mode, where the pooler is provided outside of Clowder.
+func getPoolerConfig(secrets []core.Secret, appName string, dbCfg *config.DatabaseConfig) (bool, error) {
+	for _, secret := range secrets {
+		if v, ok := secret.GetAnnotations()[PoolerAnnotation]; !ok || v != appName {
+			continue
+		}
+		port, err := strconv.ParseUint(string(secret.Data["db.port"]), 10, 16)
+		if err != nil {
+			return false, fmt.Errorf("failed to parse pooler port of secret [%s]: %w", secret.Name, err)
+		}
+		dbCfg.Hostname = string(secret.Data["db.host"])
+		dbCfg.Port = int(port)
+		return true, nil
+	}
+	return false, nil
+}
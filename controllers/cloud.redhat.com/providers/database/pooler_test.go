@@ -0,0 +1,45 @@
+package database
+
+import (
+	"strings"
+	"testing"
+
+	crd "github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1alpha1"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoolerSpec(t *testing.T) {
+	env := &crd.ClowdEnvironment{}
+	app := &crd.ClowdApp{}
+
+	assert.Nil(t, getPoolerSpec(env, app))
+
+	env.Spec.Providers.Database.Pooler = &crd.DatabasePooler{Mode: "session", PoolSize: 10}
+	app.Spec.Database.Pooler = &crd.DatabasePooler{PoolSize: 5}
+
+	pooler := getPoolerSpec(env, app)
+	assert.Equal(t, "session", pooler.Mode)
+	assert.Equal(t, int32(5), pooler.PoolSize)
+	assert.Equal(t, DefaultPoolerMaxClientConnections, pooler.MaxClientConnections)
+}
+
+func TestPoolerConfig(t *testing.T) {
+	dbCfg := &config.DatabaseConfig{
+		Hostname: "app-db.ns.svc",
+		Port:     5432,
+		Username: "user",
+		Password: `pa"ss`,
+	}
+	pooler := &crd.DatabasePooler{Mode: "transaction", PoolSize: 20, MaxClientConnections: 100}
+
+	data := makePoolerConfig(dbCfg, pooler)
+	assert.Contains(t, data["pgbouncer.ini"], "* = host=app-db.ns.svc port=5432\n")
+	assert.Contains(t, data["pgbouncer.ini"], "pool_mode = transaction\n")
+	assert.Equal(t, "\"user\" \"pa\"\"ss\"\n", data["userlist.txt"])
+
+	dbCfg.AdminUsername = "postgres"
+	dbCfg.AdminPassword = "admin"
+	data = makePoolerConfig(dbCfg, pooler)
+	assert.Equal(t, 2, strings.Count(data["userlist.txt"], "\n"))
+}
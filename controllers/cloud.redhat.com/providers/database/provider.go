@@ -39,6 +39,7 @@ func GetDatabase(c *p.Provider) (p.ClowderProvider, error) {
 
 func init() {
 	p.ProvidersRegistration.Register(GetDatabase, 5, ProvName)
+	p.ProvidersRegistration.RegisterAppFinalizer(ProvName)
 	imageList = map[int32]string{
 		15: DefaultImageDatabasePG15,
 		14: DefaultImageDatabasePG14,
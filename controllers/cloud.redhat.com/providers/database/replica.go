@@ -0,0 +1,101 @@
+package database
+
+import (
+	"fmt"
+
+	crd "github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1alpha1"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/config"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/sizing"
+	provutils "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/utils"
+
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	rc "github.com/RedHatInsights/rhc-osdk-utils/resourceCache"
+	"github.com/RedHatInsights/rhc-osdk-utils/utils"
+)
+
+// LocalDBReplicaDeployment is the ident referring to the local DB read replica deployment object.
+var LocalDBReplicaDeployment = rc.NewSingleResourceIdent(ProvName, "local_db_replica_deployment", &apps.Deployment{})
+
+// LocalDBReplicaService is the ident referring to the local DB read replica service object.
+var LocalDBReplicaService = rc.NewSingleResourceIdent(ProvName, "local_db_replica_service", &core.Service{})
+
+// The replica takes a base backup of the primary the first time it starts and
+// then follows it by streaming replication, serving read only queries.
+const replicaScript = `set -e
+export PGDATA=/var/lib/pgsql/data/userdata
+if [ ! -s "${PGDATA}/PG_VERSION" ]; then
+  until pg_isready -h "${PRIMARY_HOST}" -q; do echo "waiting for primary"; sleep 2; done
+  rm -rf "${PGDATA}"
+  pg_basebackup -h "${PRIMARY_HOST}" -U postgres -D "${PGDATA}" -R -X stream
+fi
+exec postgres -D "${PGDATA}"
+`
+
+func getLocalReplicaNamespacedName(app *crd.ClowdApp) types.NamespacedName {
+	return types.NamespacedName{
+		Name:      fmt.Sprintf("%s-db-replica", app.Name),
+		Namespace: app.Namespace,
+	}
+}
+
+// useLocalReplicas adds the read replicas of the local database of the given
+// app, which is the app sharing its database when sharedDbAppName is used.
+func useLocalReplicas(dbCfg *config.DatabaseConfig, app *crd.ClowdApp) {
+	if app.Spec.Database.ReadReplicas == 0 {
+		return
+	}
+	nn := getLocalReplicaNamespacedName(app)
+	dbCfg.ReadReplicas = []config.DatabaseReplicaConfig{{
+		Hostname: fmt.Sprintf("%s.%s.svc", nn.Name, nn.Namespace),
+		Port:     5432,
+	}}
+}
+
+// makeLocalReplicas runs the read replicas of the local database of the app,
+// all of them behind a single service.
+func makeLocalReplicas(p *providers.Provider, app *crd.ClowdApp, primary types.NamespacedName, dbCfg *config.DatabaseConfig, image string) error {
+	if app.Spec.Database.ReadReplicas == 0 {
+		return nil
+	}
+
+	nn := getLocalReplicaNamespacedName(app)
+	labels := &map[string]string{"sub": "local_db_replica"}
+
+	dd := &apps.Deployment{}
+	if err := p.Cache.Create(LocalDBReplicaDeployment, nn, dd); err != nil {
+		return err
+	}
+
+	resources := sizing.GetResourceRequirementsForSize(app.Spec.Database.DBResourceSize)
+	provutils.MakeLocalDB(dd, nn, app, labels, dbCfg, image, false, app.Spec.Database.Name, &resources)
+
+	dd.Spec.Replicas = utils.Int32Ptr(int(app.Spec.Database.ReadReplicas))
+	c := &dd.Spec.Template.Spec.Containers[0]
+	c.Command = []string{"/bin/bash", "-c", replicaScript}
+	c.Env = append(c.Env, core.EnvVar{
+		Name:  "PRIMARY_HOST",
+		Value: fmt.Sprintf("%s.%s.svc", primary.Name, primary.Namespace),
+	})
+
+	if err := p.Cache.Update(LocalDBReplicaDeployment, dd); err != nil {
+		return err
+	}
+
+	s := &core.Service{}
+	if err := p.Cache.Create(LocalDBReplicaService, nn, s); err != nil {
+		return err
+	}
+
+	provutils.MakeLocalDBService(s, nn, app, labels)
+
+	if err := p.Cache.Update(LocalDBReplicaService, s); err != nil {
+		return err
+	}
+
+	useLocalReplicas(dbCfg, app)
+	return nil
+}
@@ -0,0 +1,275 @@
+package database
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	crd "github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1alpha1"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/clowderconfig"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/errors"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/object"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers"
+	provutils "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/utils"
+
+	batch "k8s.io/api/batch/v1"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	rc "github.com/RedHatInsights/rhc-osdk-utils/resourceCache"
+	"github.com/RedHatInsights/rhc-osdk-utils/utils"
+)
+
+var DefaultImageDatabaseSeedMinioClient = "quay.io/minio/mc:RELEASE.2023-01-28T20-29-38Z"
+
+// DatabaseSeedJob is the ident referring to the job which seeds the database.
+var DatabaseSeedJob = rc.NewMultiResourceIdent(ProvName, "database_seed_job", &batch.Job{})
+
+// DatabaseSeedSecret is the ident referring to the object store credentials used by the seed job.
+var DatabaseSeedSecret = rc.NewSingleResourceIdent(ProvName, "database_seed_secret", &core.Secret{})
+
+// The seed is applied in the same transaction as its id is recorded, so a
+// seed is only ever applied once, however many times the job runs.
+const seedScript = `set -e
+until pg_isready -q; do echo "waiting for database"; sleep 2; done
+psql -v ON_ERROR_STOP=1 -c 'CREATE TABLE IF NOT EXISTS clowder_seed (id text PRIMARY KEY, applied_at timestamptz NOT NULL DEFAULT now())'
+if [ "$(psql -tA -c "SELECT 1 FROM clowder_seed WHERE id = '${SEED_ID}'")" = "1" ]; then
+  echo "database already seeded with ${SEED_ID}"
+  exit 0
+fi
+psql -v ON_ERROR_STOP=1 --single-transaction -f "${SEED_FILE}" -c "INSERT INTO clowder_seed (id) VALUES ('${SEED_ID}')"
+`
+
+const fetchScript = `set -e
+mc --config-dir /seed/.mc alias set seed "http://${MINIO_HOSTNAME}:${MINIO_PORT}" "${MINIO_ACCESS_KEY}" "${MINIO_SECRET_KEY}"
+mc --config-dir /seed/.mc cp "seed/${SEED_BUCKET}/${SEED_OBJECT}" /seed/seed.sql
+`
+
+// GetSeedID returns an identifier for the seed source of the app, it changes
+// whenever the seed source changes.
+func GetSeedID(app *crd.ClowdApp) string {
+	data, _ := json.Marshal(app.Spec.Database.Seed)
+	return fmt.Sprintf("%x", sha256.Sum256(data))
+}
+
+// GetSeedJobName returns the name of the job seeding the database of the app.
+func GetSeedJobName(app *crd.ClowdApp) string {
+	return fmt.Sprintf("%s-db-seed-%s", app.Name, GetSeedID(app)[:8])
+}
+
+func getMinioClientImage() string {
+	if clowderconfig.LoadedConfig.Images.MinioClient != "" {
+		return clowderconfig.LoadedConfig.Images.MinioClient
+	}
+	return DefaultImageDatabaseSeedMinioClient
+}
+
+// makeSeedJob creates a job which loads the seed of the app into its
+// database, using the credentials from the <app>-db secret.
+func makeSeedJob(p *providers.Provider, app *crd.ClowdApp, image string) error {
+	seed := app.Spec.Database.Seed
+	if seed == nil {
+		return nil
+	}
+
+	if seed.ObjectStore != nil {
+		if err := makeSeedSecret(p, app); err != nil {
+			return err
+		}
+	}
+
+	nn := types.NamespacedName{
+		Name:      GetSeedJobName(app),
+		Namespace: app.Namespace,
+	}
+
+	job := &batch.Job{}
+	if err := p.Cache.Create(DatabaseSeedJob, nn, job); err != nil {
+		return err
+	}
+
+	// The pod template of a job is immutable and a change to the seed source
+	// gives a new job name, so an existing job is left as it is.
+	if job.GetUID() != "" {
+		return p.Cache.Update(DatabaseSeedJob, job)
+	}
+
+	labels := app.GetLabels()
+	labels["pod"] = nn.Name
+	app.SetObjectMeta(job, crd.Name(nn.Name), crd.Labels(labels))
+	utils.UpdateAnnotations(job, provutils.KubeLinterAnnotations)
+
+	secretName := fmt.Sprintf("%s-db", app.Name)
+	secretEnv := func(name string, key string) core.EnvVar {
+		return core.EnvVar{
+			Name: name,
+			ValueFrom: &core.EnvVarSource{
+				SecretKeyRef: &core.SecretKeySelector{
+					LocalObjectReference: core.LocalObjectReference{Name: secretName},
+					Key:                  key,
+				},
+			},
+		}
+	}
+
+	c := core.Container{
+		Name:    "seed",
+		Image:   image,
+		Command: []string{"/bin/bash", "-c", seedScript},
+		Env: []core.EnvVar{
+			secretEnv("PGHOST", "hostname"),
+			secretEnv("PGPORT", "port"),
+			secretEnv("PGUSER", "username"),
+			secretEnv("PGPASSWORD", "password"),
+			secretEnv("PGDATABASE", "name"),
+			{Name: "SEED_ID", Value: GetSeedID(app)},
+		},
+		Resources: p.Env.Spec.ResourceDefaults,
+		VolumeMounts: []core.VolumeMount{{
+			Name:      "seed",
+			MountPath: "/seed",
+		}},
+		TerminationMessagePath:   "/dev/termination-log",
+		TerminationMessagePolicy: core.TerminationMessageReadFile,
+		ImagePullPolicy:          core.PullIfNotPresent,
+	}
+
+	volume := core.Volume{Name: "seed"}
+	var initContainers []core.Container
+
+	switch {
+	case seed.ConfigMap != nil:
+		key := seed.ConfigMap.Key
+		if key == "" {
+			key = "seed.sql"
+		}
+		volume.VolumeSource = core.VolumeSource{
+			ConfigMap: &core.ConfigMapVolumeSource{
+				LocalObjectReference: core.LocalObjectReference{Name: seed.ConfigMap.Name},
+				Items:                []core.KeyToPath{{Key: key, Path: key}},
+				DefaultMode:          utils.Int32Ptr(420),
+			},
+		}
+		c.Env = append(c.Env, core.EnvVar{Name: "SEED_FILE", Value: fmt.Sprintf("/seed/%s", key)})
+	case seed.PVC != nil:
+		volume.VolumeSource = core.VolumeSource{
+			PersistentVolumeClaim: &core.PersistentVolumeClaimVolumeSource{
+				ClaimName: seed.PVC.ClaimName,
+				ReadOnly:  true,
+			},
+		}
+		c.VolumeMounts[0].ReadOnly = true
+		c.Env = append(c.Env, core.EnvVar{Name: "SEED_FILE", Value: fmt.Sprintf("/seed/%s", strings.TrimPrefix(seed.PVC.Path, "/"))})
+	case seed.ObjectStore != nil:
+		volume.VolumeSource = core.VolumeSource{EmptyDir: &core.EmptyDirVolumeSource{}}
+		initContainers = append(initContainers, makeSeedFetchContainer(p, app, seed.ObjectStore))
+		c.Env = append(c.Env, core.EnvVar{Name: "SEED_FILE", Value: "/seed/seed.sql"})
+	default:
+		return errors.NewClowderError("No database seed source set")
+	}
+
+	job.Spec.BackoffLimit = utils.Int32Ptr(6)
+	job.Spec.Template.ObjectMeta.Labels = labels
+	utils.UpdateAnnotations(&job.Spec.Template, provutils.KubeLinterAnnotations)
+	job.Spec.Template.Spec = core.PodSpec{
+		InitContainers:                initContainers,
+		Containers:                    []core.Container{c},
+		Volumes:                       []core.Volume{volume},
+		RestartPolicy:                 core.RestartPolicyNever,
+		ServiceAccountName:            app.GetClowdSAName(),
+		TerminationGracePeriodSeconds: utils.Int64Ptr(30),
+		SecurityContext:               &core.PodSecurityContext{},
+		SchedulerName:                 "default-scheduler",
+		DNSPolicy:                     core.DNSClusterFirst,
+	}
+
+	return p.Cache.Update(DatabaseSeedJob, job)
+}
+
+func getSeedSecretName(app *crd.ClowdApp) string {
+	return fmt.Sprintf("%s-db-seed", app.Name)
+}
+
+// makeSeedSecret copies the credentials of the environment's MinIO into the
+// namespace of the app, so that the seed job can read from the object store.
+func makeSeedSecret(p *providers.Provider, app *crd.ClowdApp) error {
+	nn := types.NamespacedName{
+		Name:      getSeedSecretName(app),
+		Namespace: app.Namespace,
+	}
+
+	return makeMinioSecret(p, app, DatabaseSeedSecret, nn, "Database seeding")
+}
+
+// makeMinioSecret copies the credentials of the environment's MinIO into a
+// secret owned by obj, for jobs which read from or write to the object store.
+func makeMinioSecret(p *providers.Provider, obj object.ClowdObject, ident rc.ResourceIdent, nn types.NamespacedName, purpose string) error {
+	if p.Env.Spec.Providers.ObjectStore.Mode != "minio" {
+		return errors.NewClowderError(fmt.Sprintf("%s from the object store requires the minio object store mode", purpose))
+	}
+
+	minioSecret := &core.Secret{}
+
+	// This is a REAL call here, the MinIO secret is created by the environment
+	// reconciliation.
+	if err := p.Client.Get(p.Ctx, providers.GetNamespacedName(p.Env, "minio"), minioSecret); err != nil {
+		return errors.Wrap("Couldn't get minio secret", err)
+	}
+
+	secret := &core.Secret{}
+	if err := p.Cache.Create(ident, nn, secret); err != nil {
+		return err
+	}
+
+	secret.SetName(nn.Name)
+	secret.SetNamespace(nn.Namespace)
+	secret.SetLabels(obj.GetLabels())
+	secret.SetOwnerReferences([]metav1.OwnerReference{obj.MakeOwnerReference()})
+	secret.Type = core.SecretTypeOpaque
+	secret.StringData = map[string]string{}
+	for _, key := range []string{"accessKey", "secretKey", "hostname", "port"} {
+		secret.StringData[key] = string(minioSecret.Data[key])
+	}
+
+	return p.Cache.Update(ident, secret)
+}
+
+// makeSeedFetchContainer returns a container which downloads the seed from the
+// object store into the seed volume.
+func makeSeedFetchContainer(p *providers.Provider, app *crd.ClowdApp, source *crd.DatabaseSeedObjectStore) core.Container {
+	secretEnv := func(name string, key string) core.EnvVar {
+		return core.EnvVar{
+			Name: name,
+			ValueFrom: &core.EnvVarSource{
+				SecretKeyRef: &core.SecretKeySelector{
+					LocalObjectReference: core.LocalObjectReference{Name: getSeedSecretName(app)},
+					Key:                  key,
+				},
+			},
+		}
+	}
+
+	return core.Container{
+		Name:    "fetch",
+		Image:   getMinioClientImage(),
+		Command: []string{"/bin/sh", "-c", fetchScript},
+		Env: []core.EnvVar{
+			secretEnv("MINIO_HOSTNAME", "hostname"),
+			secretEnv("MINIO_PORT", "port"),
+			secretEnv("MINIO_ACCESS_KEY", "accessKey"),
+			secretEnv("MINIO_SECRET_KEY", "secretKey"),
+			{Name: "SEED_BUCKET", Value: source.Bucket},
+			{Name: "SEED_OBJECT", Value: source.Key},
+		},
+		Resources: p.Env.Spec.ResourceDefaults,
+		VolumeMounts: []core.VolumeMount{{
+			Name:      "seed",
+			MountPath: "/seed",
+		}},
+		TerminationMessagePath:   "/dev/termination-log",
+		TerminationMessagePolicy: core.TerminationMessageReadFile,
+		ImagePullPolicy:          core.PullIfNotPresent,
+	}
+}
@@ -1,15 +1,9 @@
 package database
 
 import (
-	"context"
 	"fmt"
 	"strconv"
 	"strings"
-	"time"
-
-	"database/sql"
-
-	_ "github.com/lib/pq" // Required to load postgres
 
 	crd "github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1alpha1"
 	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/config"
@@ -20,6 +14,7 @@ import (
 
 	apps "k8s.io/api/apps/v1"
 	core "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
@@ -54,6 +49,15 @@ func NewSharedDBProvider(p *providers.Provider) (providers.ClowderProvider, erro
 		SharedDBPVC,
 		SharedDBSecret,
 		SharedDBAppSecret,
+		DatabaseSeedJob,
+		DatabaseSeedSecret,
+		DatabaseBackupCronJob,
+		DatabaseBackupPVC,
+		DatabaseBackupSecret,
+		DatabaseRestoreJob,
+		DatabasePoolerDeployment,
+		DatabasePoolerService,
+		DatabasePoolerSecret,
 	)
 	return &sharedDbProvider{Provider: *p}, nil
 }
@@ -138,6 +142,7 @@ func createVersionedDatabase(p *providers.Provider, version int32) (*config.Data
 	}
 
 	defaultVolSize := sizing.GetDefaultSizeVol()
+	backupVolSize := defaultVolSize
 
 	if p.Env.Spec.Providers.Database.PVC {
 		pvc := &core.PersistentVolumeClaim{}
@@ -169,6 +174,7 @@ func createVersionedDatabase(p *providers.Provider, version int32) (*config.Data
 
 		}
 
+		backupVolSize = largestDBVolSize
 		provutils.MakeLocalDBPVC(pvc, nn, p.Env, sizing.GetVolCapacityForSize(largestDBVolSize))
 
 		if err = p.Cache.Update(SharedDBPVC, pvc); err != nil {
@@ -176,6 +182,17 @@ func createVersionedDatabase(p *providers.Provider, version int32) (*config.Data
 		}
 	}
 
+	instance := &databaseInstance{
+		owner:      p.Env,
+		nn:         nn,
+		secretName: nn.Name,
+		image:      image,
+		volumeSize: backupVolSize,
+	}
+	if err := makeBackup(p, instance); err != nil {
+		return nil, err
+	}
+
 	return &dbCfg, nil
 }
 
@@ -190,7 +207,7 @@ func (db *sharedDbProvider) EnvProvide() error {
 	for _, app := range appList.Items {
 		if app.Spec.Database.Name != "" {
 			if app.Spec.Database.Version == nil {
-				versionsRequired[12] = true
+				versionsRequired[crd.DefaultDatabaseVersion] = true
 			} else {
 				versionsRequired[*app.Spec.Database.Version] = true
 			}
@@ -217,17 +234,23 @@ func (db *sharedDbProvider) Provide(app *crd.ClowdApp) error {
 		return nil
 	}
 
+	if app.Spec.Database.ReadReplicas != 0 {
+		return errors.NewClowderError("Read replicas are not supported in shared mode")
+	}
+
+	if getEngine(app) != EnginePostgreSQL {
+		return errors.NewClowderError("Only the postgresql engine is supported in shared mode")
+	}
+
 	if app.Spec.Database.SharedDBAppName != "" {
 		return db.processSharedDB(app)
 	}
 
-	version := int32(12)
+	version := crd.DefaultDatabaseVersion
 	if app.Spec.Database.Version != nil {
 		version = *app.Spec.Database.Version
 	}
 
-	var dbCfg config.DatabaseConfig
-
 	vSec := &core.Secret{}
 	vSecnn := types.NamespacedName{
 		Name:      fmt.Sprintf("%s-db-v%s", db.Env.Name, strconv.Itoa(int(version))),
@@ -244,78 +267,61 @@ func (db *sharedDbProvider) Provide(app *crd.ClowdApp) error {
 		return err
 	}
 
-	dbCfg.AdminUsername = "postgres"
-	dbCfg.AdminPassword = string(vSec.Data["pgPass"])
-	dbCfg.Hostname = string(vSec.Data["hostname"])
-	dbCfg.Name = app.Spec.Database.Name
-	dbCfg.Password = string(vSec.Data["password"])
-	dbCfg.Username = string(vSec.Data["username"])
-	dbCfg.Port = int(port)
-	dbCfg.SslMode = "disable"
-
-	host := dbCfg.Hostname
-	user := dbCfg.AdminUsername
-	password := dbCfg.AdminPassword
-	dbname := app.Spec.Database.Name
-
-	appSQLConnectionString := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable", host, port, user, password, dbname)
-
-	ctx, cancel := context.WithTimeout(db.Ctx, 5*time.Second)
-	defer cancel()
+	nn := types.NamespacedName{
+		Name:      fmt.Sprintf("%v-db", app.Name),
+		Namespace: app.Namespace,
+	}
 
-	dbClient, err := sql.Open("postgres", appSQLConnectionString)
-	if err != nil {
+	secret := &core.Secret{}
+	if err := db.Cache.Create(SharedDBAppSecret, nn, secret); err != nil {
 		return err
 	}
 
-	defer dbClient.Close()
-
-	pErr := dbClient.PingContext(ctx)
-	if pErr != nil {
-		if strings.Contains(pErr.Error(), fmt.Sprintf("database \"%s\" does not exist", app.Spec.Database.Name)) {
-
-			envSQLConnectionString := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable", host, port, user, password, db.Env.Name)
-
-			envDbClient, envErr := sql.Open("postgres", envSQLConnectionString)
-			if envErr != nil {
-				return envErr
-			}
-
-			defer envDbClient.Close()
-
-			sqlStatement := fmt.Sprintf("CREATE DATABASE \"%s\" WITH OWNER=\"%s\";", app.Spec.Database.Name, dbCfg.Username)
-			preppedStatement, err := envDbClient.PrepareContext(ctx, sqlStatement)
-			if err != nil {
-				return err
-			}
-			_, createErr := preppedStatement.Exec()
-			if createErr != nil {
-				return createErr
-			}
-		} else {
-			return pErr
+	// Apps reconciled before every app had its own role hold the password of
+	// the server user, so their password is regenerated along with the role.
+	password := string(secret.Data["password"])
+	if password == "" || password == string(vSec.Data["password"]) {
+		if password, err = utils.RandPassword(16, provutils.RCharSet); err != nil {
+			return errors.Wrap("password generate failed", err)
 		}
 	}
 
-	nn := types.NamespacedName{
-		Name:      fmt.Sprintf("%v-db", app.Name),
-		Namespace: app.Namespace,
+	dbCfg := config.DatabaseConfig{
+		Hostname: string(vSec.Data["hostname"]),
+		Port:     int(port),
+		Name:     app.Spec.Database.Name,
+		Username: getSharedDBRoleName(app),
+		Password: password,
+		SslMode:  "disable",
 	}
 
-	secret := &core.Secret{}
-	if err := db.Cache.Create(SharedDBAppSecret, nn, secret); err != nil {
-		return err
+	server := sharedDBServer{
+		hostname: dbCfg.Hostname,
+		port:     port,
+		password: string(vSec.Data["pgPass"]),
+		user:     string(vSec.Data["username"]),
+		name:     db.Env.Name,
+	}
+
+	if err := server.ensureAppDatabase(db.Ctx, &dbCfg); err != nil {
+		return errors.Wrap("couldn't create app database", err)
 	}
 
+	secret.Data = nil
 	secret.StringData = map[string]string{
-		"hostname": host,
-		"port":     "5432",
+		"hostname": dbCfg.Hostname,
+		"port":     strconv.FormatUint(port, 10),
 		"username": dbCfg.Username,
 		"password": dbCfg.Password,
-		"pgPass":   dbCfg.AdminPassword,
 		"name":     app.Spec.Database.Name,
 	}
 
+	if app.Spec.Database.AdminCredentials {
+		dbCfg.AdminUsername = "postgres"
+		dbCfg.AdminPassword = server.password
+		secret.StringData["pgPass"] = server.password
+	}
+
 	secret.Name = nn.Name
 	secret.Namespace = nn.Namespace
 	secret.ObjectMeta.OwnerReferences = []metav1.OwnerReference{app.MakeOwnerReference()}
@@ -325,12 +331,71 @@ func (db *sharedDbProvider) Provide(app *crd.ClowdApp) error {
 		return err
 	}
 
+	if err := makeSeedJob(&db.Provider, app, imageList[version]); err != nil {
+		return err
+	}
+
 	dbCfg.Name = app.Spec.Database.Name
+
+	if err := makePooler(&db.Provider, app, &dbCfg); err != nil {
+		return err
+	}
+
 	db.Config.Database = &dbCfg
 
 	return nil
 }
 
+// FinalizeApp drops the role of a deleted app from the shared database server.
+func (db *sharedDbProvider) FinalizeApp(app *crd.ClowdApp) error {
+	if app.Spec.Database.Name == "" || app.Spec.Database.SharedDBAppName != "" {
+		return nil
+	}
+
+	version := crd.DefaultDatabaseVersion
+	if app.Spec.Database.Version != nil {
+		version = *app.Spec.Database.Version
+	}
+
+	vSec := &core.Secret{}
+	vSecnn := types.NamespacedName{
+		Name:      fmt.Sprintf("%s-db-v%s", db.Env.Name, strconv.Itoa(int(version))),
+		Namespace: db.Env.Status.TargetNamespace,
+	}
+
+	// Without the server there is no role left to drop
+	if err := db.Client.Get(db.Ctx, vSecnn, vSec); err != nil {
+		if k8serr.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	port, err := strconv.ParseUint(string(vSec.Data["port"]), 10, 16)
+	if err != nil {
+		return err
+	}
+
+	server := sharedDBServer{
+		hostname: string(vSec.Data["hostname"]),
+		port:     port,
+		password: string(vSec.Data["pgPass"]),
+		user:     string(vSec.Data["username"]),
+		name:     db.Env.Name,
+	}
+
+	err = server.dropAppRole(db.Ctx, getSharedDBRoleName(app), app.Spec.Database.Name)
+
+	// An unreachable server, such as one whose pod is already gone while its namespace is torn
+	// down, must not keep the app from being deleted. The role is left behind instead.
+	if err != nil && !isServerError(err) {
+		db.Log.Info("Could not reach the shared database server, leaving the role of the app behind", "app", app.Name, "err", err)
+		return nil
+	}
+
+	return err
+}
+
 func (db *sharedDbProvider) processSharedDB(app *crd.ClowdApp) error {
 	err := checkDependency(app)
 
@@ -370,7 +435,13 @@ func (db *sharedDbProvider) processSharedDB(app *crd.ClowdApp) error {
 	if err != nil {
 		return errors.Wrap("couldn't convert to int", err)
 	}
-	dbCfg.AdminUsername = "postgres"
+
+	// The admin credentials are only handed out to apps which asked for them
+	if dbCfg.AdminPassword != "" {
+		dbCfg.AdminUsername = "postgres"
+	}
+
+	usePooler(&dbCfg, db.Env, refApp)
 
 	db.Config.Database = &dbCfg
 
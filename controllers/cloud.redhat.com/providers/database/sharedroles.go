@@ -0,0 +1,185 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	errlib "errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+
+	crd "github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1alpha1"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/config"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/errors"
+)
+
+// sharedDBServer holds the admin credentials of a shared database server, along with its server
+// user, which owns the database of the environment.
+type sharedDBServer struct {
+	hostname string
+	port     uint64
+	password string
+	user     string
+	name     string
+}
+
+// getSharedDBRoleName returns the name of the role of the app on the shared database server. The
+// hash of the namespace and name keeps the role of every app unique within the 63 characters
+// postgres allows.
+func getSharedDBRoleName(app *crd.ClowdApp) string {
+	name := strings.ReplaceAll(app.Name, "-", "_")
+	if len(name) > 40 {
+		name = name[:40]
+	}
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(fmt.Sprintf("%s/%s", app.Namespace, app.Name))))
+	return fmt.Sprintf("%s_%s", name, hash[:8])
+}
+
+func (s *sharedDBServer) open(dbname string) (*sql.DB, error) {
+	connectionString := fmt.Sprintf("host=%s port=%d user=postgres password=%s dbname=%s sslmode=disable", s.hostname, s.port, s.password, dbname)
+	return sql.Open("postgres", connectionString)
+}
+
+// isServerError returns whether the error was returned by the database server, as opposed to one
+// raised when the server could not be reached.
+func isServerError(err error) bool {
+	var pqErr *pq.Error
+	return errlib.As(err, &pqErr)
+}
+
+func execAll(ctx context.Context, dbClient *sql.DB, statements ...string) error {
+	for _, statement := range statements {
+		if _, err := dbClient.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ensureAppDatabase creates the role of the app and its database, owned by that role, on the
+// shared server, and makes sure that no other role can connect to it. Databases created before
+// every app had its own role are owned by the server user and are handed over to the role of
+// the app.
+func (s *sharedDBServer) ensureAppDatabase(ctx context.Context, dbCfg *config.DatabaseConfig) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	envDbClient, err := s.open(s.name)
+	if err != nil {
+		return err
+	}
+	defer envDbClient.Close()
+
+	role := pq.QuoteIdentifier(dbCfg.Username)
+	database := pq.QuoteIdentifier(dbCfg.Name)
+
+	var roleExists bool
+	if err := envDbClient.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = $1)", dbCfg.Username).Scan(&roleExists); err != nil {
+		return err
+	}
+
+	verb := "CREATE"
+	if roleExists {
+		verb = "ALTER"
+	}
+
+	// The password is always set, so that the role follows the app secret
+	if err := execAll(ctx, envDbClient, fmt.Sprintf(
+		"%s ROLE %s WITH LOGIN NOSUPERUSER NOCREATEDB NOCREATEROLE PASSWORD %s",
+		verb, role, pq.QuoteLiteral(dbCfg.Password),
+	)); err != nil {
+		return err
+	}
+
+	var owner string
+	err = envDbClient.QueryRowContext(ctx, "SELECT pg_get_userbyid(datdba) FROM pg_database WHERE datname = $1", dbCfg.Name).Scan(&owner)
+
+	switch {
+	case err == sql.ErrNoRows:
+		return execAll(ctx, envDbClient,
+			fmt.Sprintf("CREATE DATABASE %s WITH OWNER=%s", database, role),
+			fmt.Sprintf("REVOKE ALL ON DATABASE %s FROM PUBLIC", database),
+		)
+	case err != nil:
+		return err
+	case owner == dbCfg.Username:
+		return nil
+	case owner == s.user:
+		if err := execAll(ctx, envDbClient,
+			fmt.Sprintf("ALTER DATABASE %s OWNER TO %s", database, role),
+			fmt.Sprintf("REVOKE ALL ON DATABASE %s FROM PUBLIC", database),
+		); err != nil {
+			return err
+		}
+
+		appDbClient, err := s.open(dbCfg.Name)
+		if err != nil {
+			return err
+		}
+		defer appDbClient.Close()
+
+		return execAll(ctx, appDbClient,
+			fmt.Sprintf("ALTER SCHEMA public OWNER TO %s", role),
+			fmt.Sprintf("GRANT ALL ON ALL TABLES IN SCHEMA public TO %s", role),
+			fmt.Sprintf("GRANT ALL ON ALL SEQUENCES IN SCHEMA public TO %s", role),
+		)
+	default:
+		return errors.NewClowderError(fmt.Sprintf("database [%s] is owned by another app", dbCfg.Name))
+	}
+}
+
+// dropAppRole drops the role of an app from the shared server. Everything the role owns,
+// including the database of the app, is handed back to the server user, so that no data is
+// lost when an app is deleted.
+func (s *sharedDBServer) dropAppRole(ctx context.Context, roleName string, dbName string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	envDbClient, err := s.open(s.name)
+	if err != nil {
+		return err
+	}
+	defer envDbClient.Close()
+
+	var roleExists bool
+	if err := envDbClient.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = $1)", roleName).Scan(&roleExists); err != nil {
+		return err
+	}
+	if !roleExists {
+		return nil
+	}
+
+	role := pq.QuoteIdentifier(roleName)
+	user := pq.QuoteIdentifier(s.user)
+
+	var dbExists bool
+	if err := envDbClient.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM pg_database WHERE datname = $1)", dbName).Scan(&dbExists); err != nil {
+		return err
+	}
+
+	// REASSIGN OWNED only covers the objects in the database it is run in,
+	// as well as the databases themselves.
+	if dbExists {
+		appDbClient, err := s.open(dbName)
+		if err != nil {
+			return err
+		}
+		defer appDbClient.Close()
+
+		if err := execAll(ctx, appDbClient,
+			fmt.Sprintf("REASSIGN OWNED BY %s TO %s", role, user),
+			fmt.Sprintf("DROP OWNED BY %s", role),
+		); err != nil {
+			return err
+		}
+	}
+
+	return execAll(ctx, envDbClient,
+		fmt.Sprintf("REASSIGN OWNED BY %s TO %s", role, user),
+		fmt.Sprintf("DROP OWNED BY %s", role),
+		fmt.Sprintf("DROP ROLE %s", role),
+	)
+}
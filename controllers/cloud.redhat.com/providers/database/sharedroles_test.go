@@ -0,0 +1,35 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/lib/pq"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	crd "github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSharedDBRoleName(t *testing.T) {
+	app := func(namespace string, name string) *crd.ClowdApp {
+		return &crd.ClowdApp{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	}
+
+	roleName := getSharedDBRoleName(app("ns-a", "my-app"))
+	assert.True(t, strings.HasPrefix(roleName, "my_app_"), roleName)
+	assert.Equal(t, roleName, getSharedDBRoleName(app("ns-a", "my-app")))
+	assert.NotEqual(t, roleName, getSharedDBRoleName(app("ns-b", "my-app")))
+
+	longName := getSharedDBRoleName(app("ns-a", strings.Repeat("a", 253)))
+	assert.LessOrEqual(t, len(longName), 63)
+}
+
+func TestIsServerError(t *testing.T) {
+	assert.True(t, isServerError(fmt.Errorf("drop role: %w", &pq.Error{Code: "2BP01"})))
+	assert.False(t, isServerError(&net.OpError{Op: "dial", Net: "tcp", Err: fmt.Errorf("connection refused")}))
+	assert.False(t, isServerError(context.DeadlineExceeded))
+}
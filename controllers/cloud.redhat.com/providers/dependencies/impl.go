@@ -11,8 +11,11 @@ import (
 
 func (dep *dependenciesProvider) makeDependencies(app *crd.ClowdApp) error {
 
-	if dep.Provider.Env.Spec.Providers.Web.PrivatePort == 0 {
-		dep.Provider.Env.Spec.Providers.Web.PrivatePort = 10000
+	// The private port is defaulted by the ClowdEnvironment webhook, this covers environments
+	// created while webhooks were disabled.
+	privatePort := dep.Provider.Env.Spec.Providers.Web.PrivatePort
+	if privatePort == 0 {
+		privatePort = crd.DefaultPrivatePort
 	}
 
 	depConfig := []config.DependencyEndpoint{}
@@ -25,7 +28,7 @@ func (dep *dependenciesProvider) makeDependencies(app *crd.ClowdApp) error {
 		&privDepConfig,
 		dep.Provider.Env.Spec.Providers.Web.Port,
 		dep.Provider.Env.Spec.Providers.Web.TLS.Port,
-		dep.Provider.Env.Spec.Providers.Web.PrivatePort,
+		privatePort,
 		dep.Provider.Env.Spec.Providers.Web.TLS.PrivatePort,
 	)
 
@@ -53,7 +56,7 @@ func (dep *dependenciesProvider) makeDependencies(app *crd.ClowdApp) error {
 		&privDepConfig,
 		dep.Provider.Env.Spec.Providers.Web.Port,
 		dep.Provider.Env.Spec.Providers.Web.TLS.Port,
-		dep.Provider.Env.Spec.Providers.Web.PrivatePort,
+		privatePort,
 		dep.Provider.Env.Spec.Providers.Web.TLS.PrivatePort,
 		app,
 		apps,
@@ -5,6 +5,7 @@ import (
 	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers"
 	rc "github.com/RedHatInsights/rhc-osdk-utils/resourceCache"
 	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
 )
 
 type deploymentProvider struct {
@@ -14,8 +15,18 @@ type deploymentProvider struct {
 // CoreDeployment is the deployment for the apps deployments.
 var CoreDeployment = rc.NewMultiResourceIdent(ProvName, "core_deployment", &apps.Deployment{})
 
+// CoreStatefulSet is the statefulset for the apps deployments with the StatefulSet workload kind.
+var CoreStatefulSet = rc.NewMultiResourceIdent(ProvName, "core_statefulset", &apps.StatefulSet{})
+
+// CoreHeadlessService is the governing headless service for the apps statefulsets.
+var CoreHeadlessService = rc.NewMultiResourceIdent(ProvName, "core_headless_service", &core.Service{})
+
 func NewDeploymentProvider(p *providers.Provider) (providers.ClowderProvider, error) {
-	p.Cache.AddPossibleGVKFromIdent(CoreDeployment)
+	p.Cache.AddPossibleGVKFromIdent(
+		CoreDeployment,
+		CoreStatefulSet,
+		CoreHeadlessService,
+	)
 	return &deploymentProvider{Provider: *p}, nil
 }
 
@@ -27,6 +38,13 @@ func (dp *deploymentProvider) Provide(app *crd.ClowdApp) error {
 
 	for _, deployment := range app.Spec.Deployments {
 
+		if deployment.IsStatefulSet() {
+			if err := dp.makeStatefulSet(deployment, app); err != nil {
+				return err
+			}
+			continue
+		}
+
 		if err := dp.makeDeployment(deployment, app); err != nil {
 			return err
 		}
@@ -250,6 +250,7 @@ func initDeployment(app *crd.ClowdApp, env *crd.ClowdEnvironment, d *apps.Deploy
 	})
 
 	d.Spec.Template.Spec.Containers = []core.Container{c}
+	d.Spec.Template.Spec.Containers = append(d.Spec.Template.Spec.Containers, ProcessSidecars(&c, &pod, env)...)
 
 	ics, err := ProcessInitContainers(nn, &c, pod.InitContainers)
 
@@ -257,17 +258,6 @@ func initDeployment(app *crd.ClowdApp, env *crd.ClowdEnvironment, d *apps.Deploy
 		return err
 	}
 
-	if pod.MachinePool != "" {
-		d.Spec.Template.Spec.Tolerations = []core.Toleration{{
-			Key:      pod.MachinePool,
-			Effect:   core.TaintEffectNoSchedule,
-			Operator: core.TolerationOpEqual,
-			Value:    "true",
-		}}
-	} else {
-		d.Spec.Template.Spec.Tolerations = []core.Toleration{}
-	}
-
 	d.Spec.Template.Spec.InitContainers = ics
 
 	d.Spec.Template.Spec.Volumes = pod.Volumes
@@ -289,6 +279,8 @@ func initDeployment(app *crd.ClowdApp, env *crd.ClowdEnvironment, d *apps.Deploy
 	}
 
 	ApplyPodAntiAffinity(&d.Spec.Template)
+	ProcessScheduling(&pod, &d.Spec.Template)
+	applyTopologySpreadConstraints(env, &d.Spec.Template)
 
 	return nil
 }
@@ -398,6 +390,51 @@ func ProcessInitContainers(nn types.NamespacedName, c *core.Container, ics []crd
 	return containerList, nil
 }
 
+// ProcessSidecars returns a list of containers built from the user defined sidecars in the
+// pod spec. Built-in sidecars, such as the token-refresher, are skipped as they are rendered by
+// the sidecar provider. The primary container is used to source the cdappconfig mount when a
+// sidecar requests it.
+func ProcessSidecars(c *core.Container, pod *crd.PodSpec, env *crd.ClowdEnvironment) []core.Container {
+	containerList := []core.Container{}
+
+	for _, sidecar := range pod.Sidecars {
+		if !sidecar.Enabled || sidecar.IsBuiltIn() {
+			continue
+		}
+
+		scContainer := core.Container{
+			Name:                     sidecar.Name,
+			Image:                    sidecar.Image,
+			Command:                  sidecar.Command,
+			Args:                     sidecar.Args,
+			Env:                      sidecar.Env,
+			Resources:                ProcessResources(&crd.PodSpec{Resources: sidecar.Resources}, env),
+			VolumeMounts:             sidecar.VolumeMounts,
+			LivenessProbe:            sidecar.LivenessProbe,
+			ReadinessProbe:           sidecar.ReadinessProbe,
+			TerminationMessagePath:   TerminationLogPath,
+			TerminationMessagePolicy: core.TerminationMessageReadFile,
+		}
+
+		if sidecar.InheritConfig {
+			scContainer.Env = append(
+				scContainer.Env, core.EnvVar{Name: "ACG_CONFIG", Value: "/cdapp/cdappconfig.json"},
+			)
+			for _, vm := range c.VolumeMounts {
+				if vm.Name == "config-secret" {
+					scContainer.VolumeMounts = append(scContainer.VolumeMounts, vm)
+				}
+			}
+		}
+
+		setImagePullPolicy(env, &scContainer)
+
+		containerList = append(containerList, scContainer)
+	}
+
+	return containerList
+}
+
 // ApplyPodAntiAffinity applies pod anti affinity rules to a pod template
 func ApplyPodAntiAffinity(t *core.PodTemplateSpec) {
 	labelSelector := &metav1.LabelSelector{MatchLabels: t.Labels}
@@ -418,6 +455,50 @@ func ApplyPodAntiAffinity(t *core.PodTemplateSpec) {
 	}}
 }
 
+// ProcessScheduling applies the scheduling options of the pod spec to a pod template. A user
+// defined affinity replaces the default one so this must be called after ApplyPodAntiAffinity.
+func ProcessScheduling(pod *crd.PodSpec, t *core.PodTemplateSpec) {
+	t.Spec.Tolerations = []core.Toleration{}
+	if pod.MachinePool != "" {
+		t.Spec.Tolerations = append(t.Spec.Tolerations, core.Toleration{
+			Key:      pod.MachinePool,
+			Effect:   core.TaintEffectNoSchedule,
+			Operator: core.TolerationOpEqual,
+			Value:    "true",
+		})
+	}
+	t.Spec.Tolerations = append(t.Spec.Tolerations, pod.Tolerations...)
+
+	if pod.Affinity != nil {
+		t.Spec.Affinity = pod.Affinity.DeepCopy()
+	}
+
+	t.Spec.NodeSelector = pod.NodeSelector
+	t.Spec.PriorityClassName = pod.PriorityClassName
+
+	t.Spec.TopologySpreadConstraints = nil
+	for _, constraint := range pod.TopologySpreadConstraints {
+		t.Spec.TopologySpreadConstraints = append(t.Spec.TopologySpreadConstraints, *constraint.DeepCopy())
+	}
+}
+
+// applyTopologySpreadConstraints uses the default topology spread constraints of the environment
+// if the deployment defines none. Constraints without a label selector are given one which
+// selects the pods of the deployment.
+func applyTopologySpreadConstraints(env *crd.ClowdEnvironment, t *core.PodTemplateSpec) {
+	if len(t.Spec.TopologySpreadConstraints) == 0 {
+		for _, constraint := range env.Spec.Providers.Deployment.TopologySpreadConstraints {
+			t.Spec.TopologySpreadConstraints = append(t.Spec.TopologySpreadConstraints, *constraint.DeepCopy())
+		}
+	}
+
+	for i := range t.Spec.TopologySpreadConstraints {
+		if t.Spec.TopologySpreadConstraints[i].LabelSelector == nil {
+			t.Spec.TopologySpreadConstraints[i].LabelSelector = &metav1.LabelSelector{MatchLabels: t.Labels}
+		}
+	}
+}
+
 // ProcessResources takes a pod spec and a clowd environment and returns the resource requirements
 // object.
 func ProcessResources(pod *crd.PodSpec, env *crd.ClowdEnvironment) core.ResourceRequirements {
@@ -0,0 +1,91 @@
+package deployment
+
+import (
+	"fmt"
+
+	crd "github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1alpha1"
+	"github.com/RedHatInsights/rhc-osdk-utils/utils"
+
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// GetHeadlessServiceName returns the name of the governing headless service for a StatefulSet.
+func GetHeadlessServiceName(nn types.NamespacedName) string {
+	return fmt.Sprintf("%s-headless", nn.Name)
+}
+
+func (dp *deploymentProvider) makeStatefulSet(deployment crd.Deployment, app *crd.ClowdApp) error {
+
+	ss := &apps.StatefulSet{}
+	nn := app.GetDeploymentNamespacedName(&deployment)
+
+	if err := dp.Cache.Create(CoreStatefulSet, nn, ss); err != nil {
+		return err
+	}
+
+	// The StatefulSet is built from the same pod template as a Deployment, the replicas are
+	// seeded from the existing object so that scaled up replicas are preserved.
+	d := &apps.Deployment{}
+	d.Spec.Replicas = ss.Spec.Replicas
+
+	if err := initDeployment(app, dp.Env, d, nn, &deployment); err != nil {
+		return err
+	}
+
+	initStatefulSet(d, ss, nn, &deployment)
+
+	if err := dp.Cache.Update(CoreStatefulSet, ss); err != nil {
+		return err
+	}
+
+	return dp.makeHeadlessService(app, nn, d.Spec.Template.Labels)
+}
+
+func initStatefulSet(d *apps.Deployment, ss *apps.StatefulSet, nn types.NamespacedName, deployment *crd.Deployment) {
+	ss.Name = d.Name
+	ss.Namespace = d.Namespace
+	ss.Labels = d.Labels
+	ss.OwnerReferences = d.OwnerReferences
+	utils.UpdateAnnotations(ss, d.Annotations)
+
+	ss.Kind = "StatefulSet"
+
+	ss.Spec.Replicas = d.Spec.Replicas
+	ss.Spec.Selector = d.Spec.Selector
+	ss.Spec.Template = d.Spec.Template
+	ss.Spec.ServiceName = GetHeadlessServiceName(nn)
+	ss.Spec.UpdateStrategy = apps.StatefulSetUpdateStrategy{
+		Type: apps.RollingUpdateStatefulSetStrategyType,
+	}
+	ss.Spec.PodManagementPolicy = apps.OrderedReadyPodManagement
+
+	if deployment.StatefulSet != nil {
+		ss.Spec.VolumeClaimTemplates = deployment.StatefulSet.VolumeClaimTemplates
+		if deployment.StatefulSet.PodManagementPolicy != "" {
+			ss.Spec.PodManagementPolicy = deployment.StatefulSet.PodManagementPolicy
+		}
+	}
+}
+
+func (dp *deploymentProvider) makeHeadlessService(app *crd.ClowdApp, nn types.NamespacedName, labels map[string]string) error {
+	s := &core.Service{}
+	snn := types.NamespacedName{
+		Name:      GetHeadlessServiceName(nn),
+		Namespace: nn.Namespace,
+	}
+
+	if err := dp.Cache.Create(CoreHeadlessService, snn, s); err != nil {
+		return err
+	}
+
+	app.SetObjectMeta(s, crd.Name(snn.Name), crd.Labels(labels))
+
+	s.Spec.Selector = map[string]string{"pod": nn.Name}
+	s.Spec.ClusterIP = core.ClusterIPNone
+	s.Spec.Type = core.ServiceTypeClusterIP
+	s.Spec.PublishNotReadyAddresses = true
+
+	return dp.Cache.Update(CoreHeadlessService, s)
+}
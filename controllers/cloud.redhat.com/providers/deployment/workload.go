@@ -0,0 +1,135 @@
+package deployment
+
+import (
+	"context"
+
+	crd "github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1alpha1"
+	rc "github.com/RedHatInsights/rhc-osdk-utils/resourceCache"
+
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Workload wraps the resource that backs a crd.Deployment in the resource cache. Depending on
+// the WorkloadKind of the crd.Deployment this is either an apps.Deployment or an
+// apps.StatefulSet. Providers that only need to modify the pod template should use a Workload
+// rather than fetching the apps.Deployment directly.
+type Workload struct {
+	// Object is the underlying cached resource.
+	Object client.Object
+
+	// Template points at the pod template of the underlying resource.
+	Template *core.PodTemplateSpec
+
+	ident rc.ResourceIdentMulti
+}
+
+// Kind returns the k8s kind of the underlying resource.
+func (w *Workload) Kind() string {
+	if _, ok := w.Object.(*apps.StatefulSet); ok {
+		return "StatefulSet"
+	}
+	return "Deployment"
+}
+
+// APIVersion returns the k8s API version of the underlying resource.
+func (w *Workload) APIVersion() string {
+	return "apps/v1"
+}
+
+// Update writes the workload back into the resource cache.
+func (w *Workload) Update(cache *rc.ObjectCache) error {
+	return cache.Update(w.ident, w.Object)
+}
+
+// SetReplicas sets the number of replicas of the underlying resource.
+func (w *Workload) SetReplicas(replicas int32) {
+	switch o := w.Object.(type) {
+	case *apps.Deployment:
+		o.Spec.Replicas = &replicas
+	case *apps.StatefulSet:
+		o.Spec.Replicas = &replicas
+	}
+}
+
+// GetClusterTemplate fetches the pod template of the underlying resource as it currently is
+// in the cluster, rather than in the cache. It returns nil if the resource does not exist yet.
+func (w *Workload) GetClusterTemplate(ctx context.Context, c client.Client) (*core.PodTemplateSpec, error) {
+	nn := types.NamespacedName{
+		Name:      w.Object.GetName(),
+		Namespace: w.Object.GetNamespace(),
+	}
+
+	obj := w.Object.DeepCopyObject().(client.Object)
+	if err := c.Get(ctx, nn, obj); err != nil {
+		if k8serr.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	switch o := obj.(type) {
+	case *apps.Deployment:
+		return &o.Spec.Template, nil
+	case *apps.StatefulSet:
+		return &o.Spec.Template, nil
+	}
+	return nil, nil
+}
+
+func newDeploymentWorkload(d *apps.Deployment) *Workload {
+	return &Workload{Object: d, Template: &d.Spec.Template, ident: CoreDeployment}
+}
+
+func newStatefulSetWorkload(ss *apps.StatefulSet) *Workload {
+	return &Workload{Object: ss, Template: &ss.Spec.Template, ident: CoreStatefulSet}
+}
+
+// GetWorkload fetches the cached resource backing the given crd.Deployment.
+func GetWorkload(cache *rc.ObjectCache, app *crd.ClowdApp, deployment *crd.Deployment) (*Workload, error) {
+	nn := app.GetDeploymentNamespacedName(deployment)
+
+	if deployment.IsStatefulSet() {
+		ss := &apps.StatefulSet{}
+		if err := cache.Get(CoreStatefulSet, ss, nn); err != nil {
+			return nil, err
+		}
+		return newStatefulSetWorkload(ss), nil
+	}
+
+	d := &apps.Deployment{}
+	if err := cache.Get(CoreDeployment, d, nn); err != nil {
+		return nil, err
+	}
+	return newDeploymentWorkload(d), nil
+}
+
+// ListWorkloads returns all of the cached resources backing the ClowdApp's deployments.
+func ListWorkloads(cache *rc.ObjectCache) ([]*Workload, error) {
+	workloads := []*Workload{}
+
+	dList := apps.DeploymentList{}
+	if err := cache.List(CoreDeployment, &dList); err != nil {
+		return nil, err
+	}
+
+	for _, d := range dList.Items {
+		innerDeployment := d
+		workloads = append(workloads, newDeploymentWorkload(&innerDeployment))
+	}
+
+	ssList := apps.StatefulSetList{}
+	if err := cache.List(CoreStatefulSet, &ssList); err != nil {
+		return nil, err
+	}
+
+	for _, ss := range ssList.Items {
+		innerStatefulSet := ss
+		workloads = append(workloads, newStatefulSetWorkload(&innerStatefulSet))
+	}
+
+	return workloads, nil
+}
@@ -24,6 +24,20 @@ func CreateJobResource(cji *crd.ClowdJobInvocation, env *crd.ClowdEnvironment, a
 	j.ObjectMeta.Labels = labels
 	j.ObjectMeta.Labels["job"] = job.Name
 	j.Spec.Template.ObjectMeta.Labels = labels
+
+	if err := ApplyJobSpec(env, app, nn, job, j); err != nil {
+		return err
+	}
+
+	utils.UpdateAnnotations(&j.Spec.Template, provutils.KubeLinterAnnotations, cji.Annotations)
+	utils.UpdateAnnotations(j, provutils.KubeLinterAnnotations, app.ObjectMeta.Annotations)
+
+	return nil
+}
+
+// ApplyJobSpec builds the spec of the k8s job from the Job config defined in
+// the ClowdApp, the pods get the cdappconfig of the ClowdApp mounted.
+func ApplyJobSpec(env *crd.ClowdEnvironment, app *crd.ClowdApp, nn types.NamespacedName, job *crd.Job, j *batchv1.Job) error {
 	j.Spec.ActiveDeadlineSeconds = job.ActiveDeadlineSeconds
 
 	pod := job.PodSpec
@@ -77,16 +91,7 @@ func CreateJobResource(cji *crd.ClowdJobInvocation, env *crd.ClowdEnvironment, a
 		ImagePullPolicy:          core.PullIfNotPresent,
 	}
 
-	if pod.MachinePool != "" {
-		j.Spec.Template.Spec.Tolerations = []core.Toleration{{
-			Key:      pod.MachinePool,
-			Effect:   core.TaintEffectNoSchedule,
-			Operator: core.TolerationOpEqual,
-			Value:    "true",
-		}}
-	} else {
-		j.Spec.Template.Spec.Tolerations = []core.Toleration{}
-	}
+	deployProvider.ProcessScheduling(&pod, &j.Spec.Template)
 
 	if !env.Spec.Providers.Deployment.OmitPullPolicy {
 		c.ImagePullPolicy = core.PullIfNotPresent
@@ -118,6 +123,7 @@ func CreateJobResource(cji *crd.ClowdJobInvocation, env *crd.ClowdEnvironment, a
 	})
 
 	j.Spec.Template.Spec.Containers = []core.Container{c}
+	j.Spec.Template.Spec.Containers = append(j.Spec.Template.Spec.Containers, deployProvider.ProcessSidecars(&c, &pod, env)...)
 
 	ics, err := deployProvider.ProcessInitContainers(nn, &c, pod.InitContainers)
 
@@ -132,7 +138,7 @@ func CreateJobResource(cji *crd.ClowdJobInvocation, env *crd.ClowdEnvironment, a
 		Name: "config-secret",
 		VolumeSource: core.VolumeSource{
 			Secret: &core.SecretVolumeSource{
-				SecretName: cji.Spec.AppName,
+				SecretName: app.Name,
 			},
 		},
 	})
@@ -141,8 +147,5 @@ func CreateJobResource(cji *crd.ClowdJobInvocation, env *crd.ClowdEnvironment, a
 		provutils.AddCertVolume(&j.Spec.Template.Spec, nn.Name)
 	}
 
-	utils.UpdateAnnotations(&j.Spec.Template, provutils.KubeLinterAnnotations, cji.Annotations)
-	utils.UpdateAnnotations(j, provutils.KubeLinterAnnotations, app.ObjectMeta.Annotations)
-
 	return nil
 }
@@ -0,0 +1,135 @@
+package kafka
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	crd "github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1alpha1"
+	strimzi "github.com/RedHatInsights/strimzi-client-go/apis/kafka.strimzi.io/v1beta2"
+)
+
+var (
+	readWriteOperations = []strimzi.KafkaUserSpecAuthorizationAclsElemOperationsElem{
+		strimzi.KafkaUserSpecAuthorizationAclsElemOperationsElemRead,
+		strimzi.KafkaUserSpecAuthorizationAclsElemOperationsElemWrite,
+		strimzi.KafkaUserSpecAuthorizationAclsElemOperationsElemDescribe,
+	}
+	readOperations = []strimzi.KafkaUserSpecAuthorizationAclsElemOperationsElem{
+		strimzi.KafkaUserSpecAuthorizationAclsElemOperationsElemRead,
+		strimzi.KafkaUserSpecAuthorizationAclsElemOperationsElemDescribe,
+	}
+	writeOperations = []strimzi.KafkaUserSpecAuthorizationAclsElemOperationsElem{
+		strimzi.KafkaUserSpecAuthorizationAclsElemOperationsElemWrite,
+		strimzi.KafkaUserSpecAuthorizationAclsElemOperationsElemDescribe,
+	}
+)
+
+// getTopicOperations returns the operations the role of the app on a topic it
+// declares allows it.
+func getTopicOperations(topic crd.KafkaTopicSpec) []strimzi.KafkaUserSpecAuthorizationAclsElemOperationsElem {
+	switch getTopicRole(topic) {
+	case TopicRoleConsumer:
+		return readOperations
+	case TopicRoleProducer:
+		return writeOperations
+	default:
+		return readWriteOperations
+	}
+}
+
+// getConsumerGroupPrefix returns the prefix every consumer group of the app
+// must start with. It is qualified by the namespace and delimited so that it
+// does not match the groups of an app whose name merely starts with the same
+// name, or of an app with the same name in another namespace.
+func getConsumerGroupPrefix(app *crd.ClowdApp) string {
+	return fmt.Sprintf("%s-%s-", app.Namespace, app.Name)
+}
+
+// getDependencyTopicNames returns the names of the topics declared by the apps
+// the given app depends on, which it may only read from.
+func getDependencyTopicNames(env *crd.ClowdEnvironment, app *crd.ClowdApp, appList *crd.ClowdAppList) []string {
+	deps := map[string]bool{}
+	for _, name := range app.Spec.Dependencies {
+		deps[name] = true
+	}
+	for _, name := range app.Spec.OptionalDependencies {
+		deps[name] = true
+	}
+
+	names := []string{}
+	for _, iapp := range appList.Items {
+		if iapp.Name == app.Name || !deps[iapp.Name] {
+			continue
+		}
+		for _, topic := range getAppTopics(&iapp) {
+			names = append(names, getTopicName(topic, *env, iapp.Namespace))
+		}
+	}
+	return names
+}
+
+func makeAcl(name string, resourceType strimzi.KafkaUserSpecAuthorizationAclsElemResourceType, patternType strimzi.KafkaUserSpecAuthorizationAclsElemResourcePatternType, operations []strimzi.KafkaUserSpecAuthorizationAclsElemOperationsElem) strimzi.KafkaUserSpecAuthorizationAclsElem {
+	address := "*"
+	return strimzi.KafkaUserSpecAuthorizationAclsElem{
+		Host:       &address,
+		Operations: operations,
+		Resource: strimzi.KafkaUserSpecAuthorizationAclsElemResource{
+			Name:        &name,
+			PatternType: &patternType,
+			Type:        resourceType,
+		},
+	}
+}
+
+// getKafkaUserAcls returns the ACLs of the KafkaUser of an app. The app may
+// produce to and consume from the topics it declares as its role on them
+// allows, only consume from the topics declared by the apps it depends on, and
+// only use the consumer groups it declares or that start with its own prefix.
+func getKafkaUserAcls(env *crd.ClowdEnvironment, app *crd.ClowdApp, appList *crd.ClowdAppList) []strimzi.KafkaUserSpecAuthorizationAclsElem {
+	literal := strimzi.KafkaUserSpecAuthorizationAclsElemResourcePatternTypeLiteral
+	prefix := strimzi.KafkaUserSpecAuthorizationAclsElemResourcePatternTypePrefix
+	topicType := strimzi.KafkaUserSpecAuthorizationAclsElemResourceTypeTopic
+	groupType := strimzi.KafkaUserSpecAuthorizationAclsElemResourceTypeGroup
+
+	acls := []strimzi.KafkaUserSpecAuthorizationAclsElem{}
+	seen := map[string]int{}
+
+	for _, topic := range getAppTopics(app) {
+		topicName := getTopicName(topic, *env, app.Namespace)
+		operations := getTopicOperations(topic)
+		if i, ok := seen[topicName]; ok {
+			// A topic declared more than once with different roles may be
+			// both produced to and consumed from.
+			if !reflect.DeepEqual(acls[i].Operations, operations) {
+				acls[i].Operations = readWriteOperations
+			}
+			continue
+		}
+		seen[topicName] = len(acls)
+		acls = append(acls, makeAcl(topicName, topicType, literal, operations))
+	}
+
+	for _, topicName := range getDependencyTopicNames(env, app, appList) {
+		if _, ok := seen[topicName]; ok {
+			continue
+		}
+		seen[topicName] = len(acls)
+		acls = append(acls, makeAcl(topicName, topicType, literal, readOperations))
+	}
+
+	groupPrefix := getConsumerGroupPrefix(app)
+	acls = append(acls, makeAcl(groupPrefix, groupType, prefix, readOperations))
+
+	groups := map[string]bool{}
+	for _, topic := range app.Spec.KafkaTopics {
+		group := topic.ConsumerGroup
+		if group == "" || groups[group] || strings.HasPrefix(group, groupPrefix) {
+			continue
+		}
+		groups[group] = true
+		acls = append(acls, makeAcl(group, groupType, literal, readOperations))
+	}
+
+	return acls
+}
@@ -0,0 +1,98 @@
+package kafka
+
+import (
+	"testing"
+
+	crd "github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1alpha1"
+	strimzi "github.com/RedHatInsights/strimzi-client-go/apis/kafka.strimzi.io/v1beta2"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func makeAclTestApp(name string, topics []string, deps ...string) crd.ClowdApp {
+	app := crd.ClowdApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test",
+		},
+		Spec: crd.ClowdAppSpec{
+			Dependencies: deps,
+		},
+	}
+	for _, topic := range topics {
+		app.Spec.KafkaTopics = append(app.Spec.KafkaTopics, crd.KafkaTopicSpec{TopicName: topic})
+	}
+	return app
+}
+
+func TestKafkaUserAcls(t *testing.T) {
+	env := &crd.ClowdEnvironment{ObjectMeta: metav1.ObjectMeta{Name: "env"}}
+
+	producer := makeAclTestApp("producer", []string{"events", "shared"})
+	other := makeAclTestApp("other", []string{"private"})
+	consumer := makeAclTestApp("consumer", []string{"shared"}, "producer")
+	consumer.Spec.OptionalDependencies = []string{"missing"}
+
+	appList := &crd.ClowdAppList{Items: []crd.ClowdApp{producer, other, consumer}}
+
+	acls := getKafkaUserAcls(env, &consumer, appList)
+	assert.Len(t, acls, 3)
+
+	assert.Equal(t, "shared", *acls[0].Resource.Name)
+	assert.Equal(t, strimzi.KafkaUserSpecAuthorizationAclsElemResourceTypeTopic, acls[0].Resource.Type)
+	assert.Equal(t, readWriteOperations, acls[0].Operations)
+
+	assert.Equal(t, "events", *acls[1].Resource.Name)
+	assert.Equal(t, strimzi.KafkaUserSpecAuthorizationAclsElemResourceTypeTopic, acls[1].Resource.Type)
+	assert.Equal(t, readOperations, acls[1].Operations)
+
+	assert.Equal(t, "test-consumer-", *acls[2].Resource.Name)
+	assert.Equal(t, strimzi.KafkaUserSpecAuthorizationAclsElemResourceTypeGroup, acls[2].Resource.Type)
+	assert.Equal(t, strimzi.KafkaUserSpecAuthorizationAclsElemResourcePatternTypePrefix, *acls[2].Resource.PatternType)
+	assert.Equal(t, readOperations, acls[2].Operations)
+
+	for _, acl := range acls {
+		assert.Nil(t, acl.Operation)
+		assert.NotEqual(t, "private", *acl.Resource.Name)
+	}
+}
+
+func TestKafkaUserAclsRoles(t *testing.T) {
+	env := &crd.ClowdEnvironment{ObjectMeta: metav1.ObjectMeta{Name: "env"}}
+
+	app := makeAclTestApp("app", nil)
+	app.Spec.KafkaTopics = []crd.KafkaTopicSpec{
+		{TopicName: "in", Role: TopicRoleConsumer, ConsumerGroup: "legacy-in", DeadLetter: &crd.KafkaCompanionTopicSpec{}},
+		{TopicName: "out", Role: TopicRoleProducer},
+		{TopicName: "mixed", Role: TopicRoleConsumer, ConsumerGroup: "test-app-mixed"},
+		{TopicName: "mixed", Role: TopicRoleProducer},
+		{TopicName: "again", Role: TopicRoleConsumer, ConsumerGroup: "legacy-in"},
+	}
+
+	acls := getKafkaUserAcls(env, &app, &crd.ClowdAppList{Items: []crd.ClowdApp{app}})
+	assert.Len(t, acls, 7)
+
+	expected := []struct {
+		name       string
+		operations []strimzi.KafkaUserSpecAuthorizationAclsElemOperationsElem
+	}{
+		{"in", readOperations},
+		{"in.dlq", readWriteOperations},
+		{"out", writeOperations},
+		{"mixed", readWriteOperations},
+		{"again", readOperations},
+	}
+	for i, e := range expected {
+		assert.Equal(t, e.name, *acls[i].Resource.Name)
+		assert.Equal(t, strimzi.KafkaUserSpecAuthorizationAclsElemResourceTypeTopic, acls[i].Resource.Type)
+		assert.Equal(t, e.operations, acls[i].Operations, e.name)
+	}
+
+	assert.Equal(t, "test-app-", *acls[5].Resource.Name)
+	assert.Equal(t, strimzi.KafkaUserSpecAuthorizationAclsElemResourcePatternTypePrefix, *acls[5].Resource.PatternType)
+
+	assert.Equal(t, "legacy-in", *acls[6].Resource.Name)
+	assert.Equal(t, strimzi.KafkaUserSpecAuthorizationAclsElemResourceTypeGroup, acls[6].Resource.Type)
+	assert.Equal(t, strimzi.KafkaUserSpecAuthorizationAclsElemResourcePatternTypeLiteral, *acls[6].Resource.PatternType)
+	assert.Equal(t, readOperations, acls[6].Operations)
+}
@@ -59,6 +59,10 @@ func (a *appInterface) setKafkaCA(broker *config.BrokerConfig) error {
 }
 
 func (a *appInterface) Provide(app *crd.ClowdApp) error {
+	if err := validateNoConnectors(app, "app-interface"); err != nil {
+		return err
+	}
+
 	if app.Spec.Cyndi.Enabled {
 		err := validateCyndiPipeline(a.Ctx, a.Client, app, getConnectNamespace(a.Env))
 		if err != nil {
@@ -66,10 +70,16 @@ func (a *appInterface) Provide(app *crd.ClowdApp) error {
 		}
 	}
 
-	if len(app.Spec.KafkaTopics) == 0 {
+	// Apps without topics of their own are still given the brokers to reach the schema registry
+	if len(app.Spec.KafkaTopics) == 0 && !hasSchemaRegistry(a.Env) {
+		app.Status.KafkaTopicMismatches = nil
 		return nil
 	}
 
+	if err := validateEnvTopicProducers(a.Ctx, a.Client, a.Env, app); err != nil {
+		return err
+	}
+
 	nn := types.NamespacedName{
 		Name:      a.Env.Spec.Providers.Kafka.Cluster.Name,
 		Namespace: getKafkaNamespace(a.Env),
@@ -89,33 +99,68 @@ func (a *appInterface) Provide(app *crd.ClowdApp) error {
 		Brokers: []config.BrokerConfig{brokerConfig},
 	}
 
-	for _, topic := range app.Spec.KafkaTopics {
+	if err := setSchemaRegistryConfig(&a.Provider, a.Config.Kafka); err != nil {
+		return err
+	}
+
+	mismatches := []crd.KafkaTopicMismatch{}
+
+	for _, topic := range getAppTopics(app) {
 		topicName := types.NamespacedName{
 			Namespace: getKafkaNamespace(a.Env),
 			Name:      topic.TopicName,
 		}
 
-		err := validateKafkaTopic(a.Ctx, a.Client, topicName)
+		k, err := validateKafkaTopic(a.Ctx, a.Client, topicName)
 
 		if err != nil {
 			return err
 		}
 
+		if k != nil {
+			topicMismatches, err := getTopicMismatches(topic, k)
+			if err != nil {
+				return err
+			}
+			mismatches = append(mismatches, topicMismatches...)
+		}
+
 		a.Config.Kafka.Topics = append(
 			a.Config.Kafka.Topics,
-			config.TopicConfig{
-				Name:          topic.TopicName,
-				RequestedName: topic.TopicName,
-			},
+			makeTopicConfig(topic.TopicName, topic),
 		)
 	}
+
+	linkCompanionTopics(app, a.Config.Kafka.Topics)
+
+	return a.reportTopicMismatches(app, mismatches)
+}
+
+// reportTopicMismatches records the differences between the existing KafkaTopics and the spec the
+// app requests for them in its status and as warning events, failing the reconciliation of the app
+// when the environment validates topics strictly.
+func (a *appInterface) reportTopicMismatches(app *crd.ClowdApp, mismatches []crd.KafkaTopicMismatch) error {
+	app.Status.KafkaTopicMismatches = nil
+	if len(mismatches) == 0 {
+		return nil
+	}
+
+	app.Status.KafkaTopicMismatches = mismatches
+	recordTopicMismatches(a.Ctx, app, mismatches)
+
+	if a.Env.Spec.Providers.Kafka.StrictTopicValidation {
+		newErr := errors.NewClowderError(fmt.Sprintf("KafkaTopics do not match the requested spec: %s", DescribeTopicMismatches(mismatches)))
+		newErr.Requeue = true
+		return newErr
+	}
+
 	return nil
 }
 
-func validateKafkaTopic(ctx context.Context, cl client.Client, nn types.NamespacedName) error {
+func validateKafkaTopic(ctx context.Context, cl client.Client, nn types.NamespacedName) (*strimzi.KafkaTopic, error) {
 	if cl == nil {
 		// Don't validate topics from within test suite
-		return nil
+		return nil, nil
 	}
 
 	t := strimzi.KafkaTopic{}
@@ -126,10 +171,10 @@ func validateKafkaTopic(ctx context.Context, cl client.Client, nn types.Namespac
 			Source:  "kafka",
 			Details: fmt.Sprintf("No KafkaTopic named '%s' found in namespace '%s'", nn.Name, nn.Namespace),
 		})
-		return &missingDeps
+		return nil, &missingDeps
 	}
 
-	return nil
+	return &t, nil
 }
 
 func validateBrokerService(ctx context.Context, cl client.Client, nn types.NamespacedName) error {
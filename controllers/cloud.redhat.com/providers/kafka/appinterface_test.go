@@ -1,6 +1,7 @@
 package kafka
 
 import (
+	"context"
 	"testing"
 
 	crd "github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1alpha1"
@@ -8,6 +9,9 @@ import (
 	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers"
 	"github.com/RedHatInsights/rhc-osdk-utils/utils"
 	"github.com/stretchr/testify/assert"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestAppInterface(t *testing.T) {
@@ -68,3 +72,37 @@ func TestAppInterface(t *testing.T) {
 	assert.Equal(t, topicName, topic.Name, "wrong topic name")
 	assert.Equal(t, topicName, topic.RequestedName, "wrong requested topic name")
 }
+
+func TestAppInterfaceSchemaRegistryWithoutTopics(t *testing.T) {
+	secret := &core.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "registry", Namespace: "platform-mq-prod"},
+		Data:       map[string][]byte{"url": []byte("https://registry.example.com")},
+	}
+
+	env := makeSchemaRegistryTestEnv("app-interface", "app-interface")
+	env.Spec.Providers.Kafka.SchemaRegistry.SecretRef = crd.NamespacedName{Name: "registry", Namespace: "platform-mq-prod"}
+
+	pr := providers.Provider{
+		Ctx:    context.Background(),
+		Client: fake.NewClientBuilder().WithObjects(secret).Build(),
+		Env:    env,
+		Config: &config.AppConfig{},
+	}
+
+	ai, err := NewAppInterface(&pr)
+	assert.NoError(t, err)
+	assert.NoError(t, ai.Provide(&crd.ClowdApp{}))
+
+	kafkaConfig := ai.GetConfig().Kafka
+	assert.Empty(t, kafkaConfig.Topics)
+	assert.Len(t, kafkaConfig.Brokers, 1, "wrong number of brokers")
+	assert.Equal(t, "https://registry.example.com", kafkaConfig.SchemaRegistry.Url)
+
+	// Without a schema registry apps without topics are given no Kafka config
+	env.Spec.Providers.Kafka.SchemaRegistry.Mode = "none"
+	pr.Config = &config.AppConfig{}
+	ai, err = NewAppInterface(&pr)
+	assert.NoError(t, err)
+	assert.NoError(t, ai.Provide(&crd.ClowdApp{}))
+	assert.Nil(t, ai.GetConfig().Kafka)
+}
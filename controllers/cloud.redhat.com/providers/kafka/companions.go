@@ -0,0 +1,102 @@
+package kafka
+
+import (
+	crd "github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1alpha1"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/config"
+)
+
+const (
+	// DefaultDeadLetterSuffix is appended to the name of a topic to name its dead letter topic.
+	DefaultDeadLetterSuffix = ".dlq"
+	// DefaultRetrySuffix is appended to the name of a topic to name its retry topic.
+	DefaultRetrySuffix = ".retry"
+)
+
+// makeCompanionTopic returns the spec of a dead letter or retry topic of a topic, with the
+// values it does not set taken from the topic.
+func makeCompanionTopic(topic crd.KafkaTopicSpec, companion *crd.KafkaCompanionTopicSpec, defaultSuffix string) crd.KafkaTopicSpec {
+	suffix := companion.Suffix
+	if suffix == "" {
+		suffix = defaultSuffix
+	}
+
+	companionTopic := crd.KafkaTopicSpec{
+		TopicName:      topic.TopicName + suffix,
+		Config:         companion.Config,
+		Partitions:     companion.Partitions,
+		Replicas:       companion.Replicas,
+		DeletionPolicy: topic.DeletionPolicy,
+		Cluster:        topic.Cluster,
+	}
+	if companionTopic.Config == nil {
+		companionTopic.Config = topic.Config
+	}
+	if companionTopic.Partitions == 0 {
+		companionTopic.Partitions = topic.Partitions
+	}
+	if companionTopic.Replicas == 0 {
+		companionTopic.Replicas = topic.Replicas
+	}
+	return companionTopic
+}
+
+func getDeadLetterTopic(topic crd.KafkaTopicSpec) *crd.KafkaTopicSpec {
+	if topic.DeadLetter == nil {
+		return nil
+	}
+	deadLetterTopic := makeCompanionTopic(topic, topic.DeadLetter, DefaultDeadLetterSuffix)
+	return &deadLetterTopic
+}
+
+func getRetryTopic(topic crd.KafkaTopicSpec) *crd.KafkaTopicSpec {
+	if topic.Retry == nil {
+		return nil
+	}
+	retryTopic := makeCompanionTopic(topic, topic.Retry, DefaultRetrySuffix)
+	return &retryTopic
+}
+
+// getAppTopics returns the topics the app declares, each followed by its dead letter and retry
+// topics.
+func getAppTopics(app *crd.ClowdApp) []crd.KafkaTopicSpec {
+	topics := []crd.KafkaTopicSpec{}
+
+	for _, topic := range app.Spec.KafkaTopics {
+		topics = append(topics, topic)
+		if deadLetterTopic := getDeadLetterTopic(topic); deadLetterTopic != nil {
+			topics = append(topics, *deadLetterTopic)
+		}
+		if retryTopic := getRetryTopic(topic); retryTopic != nil {
+			topics = append(topics, *retryTopic)
+		}
+	}
+
+	return topics
+}
+
+// linkCompanionTopics gives the config of each topic the app declares the names of its dead
+// letter and retry topics on the Kafka server.
+func linkCompanionTopics(app *crd.ClowdApp, topicConfigs []config.TopicConfig) {
+	names := map[string]string{}
+	for _, topicConfig := range topicConfigs {
+		names[topicConfig.RequestedName] = topicConfig.Name
+	}
+
+	for _, topic := range app.Spec.KafkaTopics {
+		for i := range topicConfigs {
+			if topicConfigs[i].RequestedName != topic.TopicName {
+				continue
+			}
+			if deadLetterTopic := getDeadLetterTopic(topic); deadLetterTopic != nil {
+				if name, ok := names[deadLetterTopic.TopicName]; ok {
+					topicConfigs[i].DeadLetterTopicName = &name
+				}
+			}
+			if retryTopic := getRetryTopic(topic); retryTopic != nil {
+				if name, ok := names[retryTopic.TopicName]; ok {
+					topicConfigs[i].RetryTopicName = &name
+				}
+			}
+		}
+	}
+}
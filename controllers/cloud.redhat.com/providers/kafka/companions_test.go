@@ -0,0 +1,68 @@
+package kafka
+
+import (
+	"testing"
+
+	crd "github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1alpha1"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAppTopics(t *testing.T) {
+	app := makeRoleTestApp("app",
+		crd.KafkaTopicSpec{
+			TopicName:      "events",
+			Partitions:     6,
+			Replicas:       2,
+			Config:         map[string]string{"retention.ms": "1000"},
+			DeletionPolicy: TopicDeletionPolicyDelete,
+			DeadLetter:     &crd.KafkaCompanionTopicSpec{},
+			Retry: &crd.KafkaCompanionTopicSpec{
+				Suffix:     "-retry",
+				Partitions: 1,
+				Config:     map[string]string{"retention.ms": "50"},
+			},
+		},
+		crd.KafkaTopicSpec{TopicName: "audit"},
+	)
+
+	assert.Equal(t, []crd.KafkaTopicSpec{
+		app.Spec.KafkaTopics[0],
+		{
+			TopicName:      "events.dlq",
+			Partitions:     6,
+			Replicas:       2,
+			Config:         map[string]string{"retention.ms": "1000"},
+			DeletionPolicy: TopicDeletionPolicyDelete,
+		},
+		{
+			TopicName:      "events-retry",
+			Partitions:     1,
+			Replicas:       2,
+			Config:         map[string]string{"retention.ms": "50"},
+			DeletionPolicy: TopicDeletionPolicyDelete,
+		},
+		app.Spec.KafkaTopics[1],
+	}, getAppTopics(&app))
+}
+
+func TestLinkCompanionTopics(t *testing.T) {
+	app := makeRoleTestApp("app",
+		crd.KafkaTopicSpec{TopicName: "events", DeadLetter: &crd.KafkaCompanionTopicSpec{}, Retry: &crd.KafkaCompanionTopicSpec{}},
+		crd.KafkaTopicSpec{TopicName: "audit"},
+	)
+
+	topicConfigs := []config.TopicConfig{
+		{RequestedName: "events", Name: "events-env"},
+		{RequestedName: "events.dlq", Name: "events.dlq-env"},
+		{RequestedName: "events.retry", Name: "events.retry-env"},
+		{RequestedName: "audit", Name: "audit-env"},
+	}
+	linkCompanionTopics(&app, topicConfigs)
+
+	assert.Equal(t, "events.dlq-env", *topicConfigs[0].DeadLetterTopicName)
+	assert.Equal(t, "events.retry-env", *topicConfigs[0].RetryTopicName)
+	assert.Nil(t, topicConfigs[1].DeadLetterTopicName)
+	assert.Nil(t, topicConfigs[3].DeadLetterTopicName)
+	assert.Nil(t, topicConfigs[3].RetryTopicName)
+}
@@ -0,0 +1,243 @@
+package kafka
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	crd "github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1alpha1"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/config"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/errors"
+	strimzi "github.com/RedHatInsights/strimzi-client-go/apis/kafka.strimzi.io/v1beta2"
+
+	"github.com/RedHatInsights/rhc-osdk-utils/utils"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rc "github.com/RedHatInsights/rhc-osdk-utils/resourceCache"
+)
+
+// KafkaConnector is the resource ident for the KafkaConnector objects of an app.
+var KafkaConnector = rc.NewMultiResourceIdent(ProvName, "kafka_connector", &strimzi.KafkaConnector{}, rc.ResourceOptions{WriteNow: true})
+
+// ConnectorAppLabel is the label holding the name of the ClowdApp a KafkaConnector belongs to.
+const ConnectorAppLabel = "clowdapp"
+
+// connectorTemplateData is the data the config values of a connector are rendered with.
+type connectorTemplateData struct {
+	// The database of the app, as given in the cdappconfig.json.
+	Database *config.DatabaseConfig
+	// The names of the topics of the app on the Kafka server, keyed by their requested names.
+	Topics map[string]string
+}
+
+func getConnectorName(env *crd.ClowdEnvironment, app *crd.ClowdApp, connector crd.KafkaConnectorSpec) string {
+	return fmt.Sprintf("%s-%s-%s", env.Name, app.Name, connector.Name)
+}
+
+func getConnectorLabels(env *crd.ClowdEnvironment, app *crd.ClowdApp) map[string]string {
+	labels := env.GetLabels()
+	labels[ConnectorAppLabel] = app.Name
+	return labels
+}
+
+func makeConnectorTemplateData(c *config.AppConfig) connectorTemplateData {
+	data := connectorTemplateData{
+		Database: c.Database,
+		Topics:   map[string]string{},
+	}
+	if c.Kafka != nil {
+		for _, topic := range c.Kafka.Topics {
+			data.Topics[topic.RequestedName] = topic.Name
+		}
+	}
+	return data
+}
+
+// renderConnectorConfig renders the config values of a connector as templates. The topic function
+// gives the name on the Kafka server of a topic the app requested.
+func renderConnectorConfig(connector crd.KafkaConnectorSpec, data connectorTemplateData) (map[string]string, error) {
+	rendered := make(map[string]string, len(connector.Config))
+
+	funcs := template.FuncMap{
+		"topic": func(name string) (string, error) {
+			topicName, ok := data.Topics[name]
+			if !ok {
+				return "", fmt.Errorf("topic [%s] is not requested by the app", name)
+			}
+			return topicName, nil
+		},
+	}
+
+	for key, value := range connector.Config {
+		tmpl, err := template.New(key).Funcs(funcs).Option("missingkey=error").Parse(value)
+		if err != nil {
+			return nil, errors.Wrap(fmt.Sprintf("connector [%s]: could not parse config [%s]", connector.Name, key), err)
+		}
+
+		var out strings.Builder
+		if err := tmpl.Execute(&out, data); err != nil {
+			return nil, errors.Wrap(fmt.Sprintf("connector [%s]: could not render config [%s]", connector.Name, key), err)
+		}
+		rendered[key] = out.String()
+	}
+
+	return rendered, nil
+}
+
+// getConnectorStatus returns the state of a KafkaConnector as reported by Strimzi.
+func getConnectorStatus(connector crd.KafkaConnectorSpec, k *strimzi.KafkaConnector) crd.KafkaConnectorStatus {
+	status := crd.KafkaConnectorStatus{
+		Name:          connector.Name,
+		ConnectorName: k.Name,
+	}
+
+	if k.Status == nil {
+		status.Message = "connector has not been reconciled"
+		return status
+	}
+
+	if k.Status.ConnectorStatus != nil {
+		connectorStatus := struct {
+			Connector struct {
+				State string `json:"state"`
+			} `json:"connector"`
+		}{}
+		if err := json.Unmarshal(k.Status.ConnectorStatus.Raw, &connectorStatus); err == nil {
+			status.State = connectorStatus.Connector.State
+		}
+	}
+
+	if k.Status.ObservedGeneration != nil && k.Generation > int64(*k.Status.ObservedGeneration) {
+		status.Message = "connector has not been reconciled"
+		return status
+	}
+
+	for _, condition := range k.Status.Conditions {
+		if condition.Type == nil || condition.Status == nil || *condition.Status != "True" {
+			continue
+		}
+		if *condition.Type == "Ready" {
+			status.Ready = true
+			status.Message = ""
+			break
+		}
+		if condition.Message != nil {
+			status.Message = *condition.Message
+		}
+	}
+
+	return status
+}
+
+// processConnectors renders the connectors of the app as KafkaConnectors of the Kafka Connect
+// cluster, deletes the ones which are no longer requested and records their state in the status
+// of the app.
+func (s *strimziProvider) processConnectors(app *crd.ClowdApp) error {
+	// The connectors run on the Kafka Connect cluster of the default Kafka cluster
+	if s.cluster != "" {
+		return nil
+	}
+
+	data := makeConnectorTemplateData(s.Config)
+	statuses := []crd.KafkaConnectorStatus{}
+	names := map[string]bool{}
+
+	for _, connector := range app.Spec.KafkaConnectors {
+		nn := types.NamespacedName{
+			Name:      getConnectorName(s.Env, app, connector),
+			Namespace: getConnectNamespace(s.Env),
+		}
+		names[nn.Name] = true
+
+		renderedConfig, err := renderConnectorConfig(connector, data)
+		if err != nil {
+			return err
+		}
+
+		var connectorConfig apiextensions.JSON
+		if connectorConfig.Raw, err = json.Marshal(renderedConfig); err != nil {
+			return fmt.Errorf("could not marshal connector config: %w", err)
+		}
+
+		k := &strimzi.KafkaConnector{}
+		if err := s.Cache.Create(KafkaConnector, nn, k); err != nil {
+			return err
+		}
+
+		labels := getConnectorLabels(s.Env, app)
+		labels["strimzi.io/cluster"] = getConnectClusterName(s.Env)
+
+		// it would be best for the ClowdApp to own this, but since cross-namespace OwnerReferences
+		// are not permitted, make this owned by the ClowdEnvironment
+		labeler := utils.GetCustomLabeler(labels, nn, s.Env)
+		labeler(k)
+
+		k.Spec = &strimzi.KafkaConnectorSpec{
+			Class:  utils.StringPtr(connector.Class),
+			Config: &connectorConfig,
+		}
+		if connector.TasksMax != 0 {
+			tasksMax := connector.TasksMax
+			k.Spec.TasksMax = &tasksMax
+		}
+
+		if err := s.Cache.Update(KafkaConnector, k); err != nil {
+			return err
+		}
+
+		statuses = append(statuses, getConnectorStatus(connector, k))
+	}
+
+	if err := s.deleteConnectors(app, names); err != nil {
+		return err
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+
+	app.Status.KafkaConnectors = nil
+	if len(statuses) > 0 {
+		app.Status.KafkaConnectors = statuses
+	}
+
+	return nil
+}
+
+// deleteConnectors deletes the KafkaConnectors of the app whose names are not kept. As they are
+// owned by the ClowdEnvironment, they are not removed along with the objects of the app.
+func (s *strimziProvider) deleteConnectors(app *crd.ClowdApp, keep map[string]bool) error {
+	connectors := strimzi.KafkaConnectorList{}
+	opts := []client.ListOption{
+		client.InNamespace(getConnectNamespace(s.Env)),
+		client.MatchingLabels(getConnectorLabels(s.Env, app)),
+	}
+	if err := s.Client.List(s.Ctx, &connectors, opts...); err != nil {
+		return errors.Wrap("could not list connectors", err)
+	}
+
+	for i := range connectors.Items {
+		connector := &connectors.Items[i]
+		if keep[connector.Name] {
+			continue
+		}
+		if err := s.Client.Delete(s.Ctx, connector); err != nil {
+			return errors.Wrap(fmt.Sprintf("could not delete connector [%s]", connector.Name), err)
+		}
+	}
+
+	return nil
+}
+
+// validateNoConnectors returns an error when the app requests connectors in a mode where Clowder
+// does not manage the Kafka Connect cluster.
+func validateNoConnectors(app *crd.ClowdApp, mode string) error {
+	if len(app.Spec.KafkaConnectors) == 0 {
+		return nil
+	}
+	return errors.NewClowderError(fmt.Sprintf("kafkaConnectors are not supported in kafka mode [%s]", mode))
+}
@@ -0,0 +1,84 @@
+package kafka
+
+import (
+	"testing"
+
+	crd "github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1alpha1"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/config"
+	"github.com/RedHatInsights/rhc-osdk-utils/utils"
+	strimzi "github.com/RedHatInsights/strimzi-client-go/apis/kafka.strimzi.io/v1beta2"
+	"github.com/stretchr/testify/assert"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRenderConnectorConfig(t *testing.T) {
+	data := makeConnectorTemplateData(&config.AppConfig{
+		Database: &config.DatabaseConfig{Hostname: "myapp-db.test.svc", Port: 5432, Name: "myapp"},
+		Kafka: &config.KafkaConfig{
+			Topics: []config.TopicConfig{{RequestedName: "events", Name: "events-env"}},
+		},
+	})
+
+	connector := crd.KafkaConnectorSpec{
+		Name:  "sink",
+		Class: "io.debezium.connector.postgresql.PostgresConnector",
+		Config: map[string]string{
+			"database.hostname": "{{ .Database.Hostname }}",
+			"database.port":     "{{ .Database.Port }}",
+			"topics":            `{{ topic "events" }}`,
+			"tombstones":        "false",
+		},
+	}
+
+	rendered, err := renderConnectorConfig(connector, data)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"database.hostname": "myapp-db.test.svc",
+		"database.port":     "5432",
+		"topics":            "events-env",
+		"tombstones":        "false",
+	}, rendered)
+
+	connector.Config = map[string]string{"topics": `{{ topic "missing" }}`}
+	_, err = renderConnectorConfig(connector, data)
+	assert.Error(t, err)
+
+	connector.Config = map[string]string{"database.hostname": "{{ .Database.Hostname }}"}
+	_, err = renderConnectorConfig(connector, makeConnectorTemplateData(&config.AppConfig{}))
+	assert.Error(t, err)
+}
+
+func TestConnectorStatus(t *testing.T) {
+	connector := crd.KafkaConnectorSpec{Name: "sink"}
+	k := &strimzi.KafkaConnector{ObjectMeta: metav1.ObjectMeta{Name: "env-myapp-sink", Generation: 2}}
+
+	status := getConnectorStatus(connector, k)
+	assert.Equal(t, "sink", status.Name)
+	assert.Equal(t, "env-myapp-sink", status.ConnectorName)
+	assert.False(t, status.Ready)
+
+	k.Status = &strimzi.KafkaConnectorStatus{
+		ObservedGeneration: utils.Int32Ptr(2),
+		ConnectorStatus:    &apiextensions.JSON{Raw: []byte(`{"connector": {"state": "FAILED"}}`)},
+		Conditions: []strimzi.KafkaConnectorStatusConditionsElem{{
+			Type:    utils.StringPtr("NotReady"),
+			Status:  utils.StringPtr("True"),
+			Message: utils.StringPtr("connector failed"),
+		}},
+	}
+	status = getConnectorStatus(connector, k)
+	assert.False(t, status.Ready)
+	assert.Equal(t, "FAILED", status.State)
+	assert.Equal(t, "connector failed", status.Message)
+
+	k.Status.ConnectorStatus = &apiextensions.JSON{Raw: []byte(`{"connector": {"state": "RUNNING"}}`)}
+	k.Status.Conditions = []strimzi.KafkaConnectorStatusConditionsElem{{
+		Type:   utils.StringPtr("Ready"),
+		Status: utils.StringPtr("True"),
+	}}
+	status = getConnectorStatus(connector, k)
+	assert.True(t, status.Ready)
+	assert.Equal(t, "RUNNING", status.State)
+	assert.Empty(t, status.Message)
+}
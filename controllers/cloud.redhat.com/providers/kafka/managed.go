@@ -28,10 +28,19 @@ func (k *managedKafkaProvider) EnvProvide() error {
 }
 
 func (k *managedKafkaProvider) Provide(app *crd.ClowdApp) error {
-	if len(app.Spec.KafkaTopics) == 0 {
+	if err := validateNoConnectors(app, "managed"); err != nil {
+		return err
+	}
+
+	// Apps without topics of their own are still given the brokers to reach the schema registry
+	if len(app.Spec.KafkaTopics) == 0 && !hasSchemaRegistry(k.Env) {
 		return nil
 	}
 
+	if err := validateEnvTopicProducers(k.Ctx, k.Client, k.Env, app); err != nil {
+		return err
+	}
+
 	var err error
 	var secret *core.Secret
 	var broker config.BrokerConfig
@@ -48,7 +57,7 @@ func (k *managedKafkaProvider) Provide(app *crd.ClowdApp) error {
 
 	k.Config.Kafka = k.getKafkaConfig(broker, app)
 
-	return nil
+	return setSchemaRegistryConfig(&k.Provider, k.Config.Kafka)
 }
 
 func (k *managedKafkaProvider) appendTopic(topic crd.KafkaTopicSpec, kafkaConfig *config.KafkaConfig) {
@@ -61,10 +70,7 @@ func (k *managedKafkaProvider) appendTopic(topic crd.KafkaTopicSpec, kafkaConfig
 
 	kafkaConfig.Topics = append(
 		kafkaConfig.Topics,
-		config.TopicConfig{
-			Name:          topicName,
-			RequestedName: topic.TopicName,
-		},
+		makeTopicConfig(topicName, topic),
 	)
 }
 
@@ -119,10 +125,12 @@ func (k *managedKafkaProvider) getKafkaConfig(broker config.BrokerConfig, app *c
 	kafkaConfig.Brokers = []config.BrokerConfig{broker}
 	kafkaConfig.Topics = []config.TopicConfig{}
 
-	for _, topic := range app.Spec.KafkaTopics {
+	for _, topic := range getAppTopics(app) {
 		k.appendTopic(topic, kafkaConfig)
 	}
 
+	linkCompanionTopics(app, kafkaConfig.Topics)
+
 	return kafkaConfig
 
 }
@@ -0,0 +1,265 @@
+package kafka
+
+import (
+	"fmt"
+
+	crd "github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1alpha1"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/config"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/errors"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers"
+	"github.com/RedHatInsights/rhc-osdk-utils/utils"
+)
+
+// multiClusterProvider runs the provider of the default Kafka cluster of the environment along
+// with the providers of its named clusters, each with the topics the apps place on it.
+type multiClusterProvider struct {
+	providers.Provider
+	defaultProvider providers.ClowderProvider
+	clusters        []namedClusterProvider
+}
+
+type namedClusterProvider struct {
+	name     string
+	provider providers.ClowderProvider
+}
+
+// NewMultiCluster returns a kafka provider serving the named clusters of the environment along
+// with its default cluster, which is served by the given provider.
+func NewMultiCluster(p *providers.Provider, defaultProvider providers.ClowderProvider) (providers.ClowderProvider, error) {
+	m := &multiClusterProvider{Provider: *p, defaultProvider: defaultProvider}
+
+	for _, cluster := range p.Env.Spec.Providers.Kafka.Clusters {
+		clusterProvider := *p
+		clusterProvider.Env = getClusterEnv(p.Env, cluster)
+		clusterProvider.Config = &config.AppConfig{}
+
+		var prov providers.ClowderProvider
+		var err error
+		switch cluster.Mode {
+		case "operator":
+			prov, err = newClusterStrimzi(&clusterProvider, cluster.Name)
+		case "app-interface":
+			prov, err = NewAppInterface(&clusterProvider)
+		case "managed":
+			prov, err = NewManagedKafka(&clusterProvider)
+		default:
+			err = errors.NewClowderError(fmt.Sprintf("No matching kafka mode for %s in cluster %s", cluster.Mode, cluster.Name))
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		m.clusters = append(m.clusters, namedClusterProvider{name: cluster.Name, provider: prov})
+	}
+
+	return m, nil
+}
+
+// GetClusterMode returns the mode of the Kafka cluster of the environment with the given name,
+// the default cluster when the name is empty, or an empty mode when there is no such cluster.
+func GetClusterMode(env *crd.ClowdEnvironment, cluster string) crd.KafkaMode {
+	if cluster == "" {
+		return env.Spec.Providers.Kafka.Mode
+	}
+	for _, namedCluster := range env.Spec.Providers.Kafka.Clusters {
+		if namedCluster.Name == cluster {
+			return namedCluster.Mode
+		}
+	}
+	return ""
+}
+
+// getClusterEnv returns a copy of the environment whose Kafka provider is configured with one of
+// its named clusters.
+func getClusterEnv(env *crd.ClowdEnvironment, cluster crd.KafkaNamedClusterConfig) *crd.ClowdEnvironment {
+	clusterEnv := env.DeepCopy()
+
+	kafka := &clusterEnv.Spec.Providers.Kafka
+	kafka.Mode = cluster.Mode
+	kafka.Cluster = cluster.Cluster
+	if kafka.Cluster.Name == "" {
+		kafka.Cluster.Name = fmt.Sprintf("%s-%s", getKafkaName(env), cluster.Name)
+	}
+	kafka.ManagedSecretRef = cluster.ManagedSecretRef
+	kafka.ManagedPrefix = cluster.ManagedPrefix
+
+	// Kafka Connect and the schema registry only run along with the default cluster
+	kafka.Connect = crd.KafkaConnectClusterConfig{}
+	kafka.SchemaRegistry = crd.SchemaRegistryConfig{}
+	kafka.Clusters = nil
+
+	kafka.ClusterName = ""
+	kafka.Namespace = ""
+	kafka.ConnectNamespace = ""
+	kafka.ConnectClusterName = ""
+
+	return clusterEnv
+}
+
+// getClusterApp returns a copy of the app with only the topics it places on the given Kafka
+// cluster. The connectors and Cyndi pipeline of the app are only kept for the default cluster.
+func getClusterApp(app *crd.ClowdApp, cluster string) *crd.ClowdApp {
+	clusterApp := app.DeepCopy()
+
+	clusterApp.Spec.KafkaTopics = []crd.KafkaTopicSpec{}
+	for _, topic := range app.Spec.KafkaTopics {
+		if topic.Cluster == cluster {
+			clusterApp.Spec.KafkaTopics = append(clusterApp.Spec.KafkaTopics, *topic.DeepCopy())
+		}
+	}
+
+	if cluster != "" {
+		clusterApp.Spec.KafkaConnectors = nil
+		clusterApp.Spec.Cyndi = crd.CyndiSpec{}
+	}
+
+	return clusterApp
+}
+
+// getClusterAppList returns the apps of the list with only the topics they place on the given
+// Kafka cluster.
+func getClusterAppList(appList *crd.ClowdAppList, cluster string) *crd.ClowdAppList {
+	clusterAppList := &crd.ClowdAppList{Items: []crd.ClowdApp{}}
+	for i := range appList.Items {
+		clusterAppList.Items = append(clusterAppList.Items, *getClusterApp(&appList.Items[i], cluster))
+	}
+	return clusterAppList
+}
+
+// validateTopicClusters checks that every topic of the app is placed on a Kafka cluster the
+// environment defines.
+func validateTopicClusters(env *crd.ClowdEnvironment, app *crd.ClowdApp) error {
+	for _, topic := range app.Spec.KafkaTopics {
+		if topic.Cluster != "" && GetClusterMode(env, topic.Cluster) == "" {
+			return errors.NewClowderError(fmt.Sprintf(
+				"topic [%s] is placed on the Kafka cluster [%s] which the environment does not define",
+				topic.TopicName, topic.Cluster,
+			))
+		}
+	}
+	return nil
+}
+
+func (m *multiClusterProvider) EnvProvide() error {
+	if err := m.defaultProvider.EnvProvide(); err != nil {
+		return err
+	}
+
+	for _, cluster := range m.clusters {
+		if err := cluster.provider.EnvProvide(); err != nil {
+			return errors.Wrap(fmt.Sprintf("kafka cluster [%s]", cluster.name), err)
+		}
+	}
+
+	return nil
+}
+
+// FinalizeApp runs the finalizers of the providers of every Kafka cluster of the environment.
+func (m *multiClusterProvider) FinalizeApp(app *crd.ClowdApp) error {
+	if finalizer, ok := m.defaultProvider.(providers.AppFinalizer); ok {
+		if err := finalizer.FinalizeApp(app); err != nil {
+			return err
+		}
+	}
+
+	for _, cluster := range m.clusters {
+		if finalizer, ok := cluster.provider.(providers.AppFinalizer); ok {
+			if err := finalizer.FinalizeApp(getClusterApp(app, cluster.name)); err != nil {
+				return errors.Wrap(fmt.Sprintf("kafka cluster [%s]", cluster.name), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+var _ providers.AppFinalizer = &multiClusterProvider{}
+
+func (m *multiClusterProvider) Provide(app *crd.ClowdApp) error {
+	if err := validateTopicClusters(m.Env, app); err != nil {
+		return err
+	}
+
+	if len(m.clusters) == 0 {
+		return m.defaultProvider.Provide(app)
+	}
+
+	if err := m.validateClusterTopicProducers(app); err != nil {
+		return err
+	}
+
+	defaultApp := getClusterApp(app, "")
+	defaultApp.Status.KafkaTopicMismatches = nil
+	err := m.defaultProvider.Provide(defaultApp)
+	app.Status = defaultApp.Status
+	if err != nil {
+		return err
+	}
+
+	for _, cluster := range m.clusters {
+		clusterApp := getClusterApp(app, cluster.name)
+		clusterApp.Status.KafkaTopicMismatches = nil
+		err := cluster.provider.Provide(clusterApp)
+		app.Status.KafkaTopicMismatches = append(app.Status.KafkaTopicMismatches, clusterApp.Status.KafkaTopicMismatches...)
+		if err != nil {
+			return errors.Wrap(fmt.Sprintf("kafka cluster [%s]", cluster.name), err)
+		}
+
+		m.addClusterTopics(cluster.name, cluster.provider.GetConfig().Kafka)
+	}
+
+	return nil
+}
+
+// addClusterTopics adds the topics of a named Kafka cluster to the config of the app, along with
+// the brokers of the cluster.
+func (m *multiClusterProvider) addClusterTopics(cluster string, clusterConfig *config.KafkaConfig) {
+	if clusterConfig == nil {
+		return
+	}
+
+	if m.Config.Kafka == nil {
+		m.Config.Kafka = &config.KafkaConfig{
+			Brokers: []config.BrokerConfig{},
+			Topics:  []config.TopicConfig{},
+		}
+	}
+
+	// The consumer group prefix of the app is the same on every cluster
+	if m.Config.Kafka.ConsumerGroupPrefix == nil {
+		m.Config.Kafka.ConsumerGroupPrefix = clusterConfig.ConsumerGroupPrefix
+	}
+
+	for _, topic := range clusterConfig.Topics {
+		topic.Cluster = utils.StringPtr(cluster)
+		topic.Brokers = clusterConfig.Brokers
+		m.Config.Kafka.Topics = append(m.Config.Kafka.Topics, topic)
+	}
+}
+
+// validateClusterTopicProducers checks that every topic the app consumes is produced by an app
+// in the environment on the same Kafka cluster.
+func (m *multiClusterProvider) validateClusterTopicProducers(app *crd.ClowdApp) error {
+	if m.Client == nil {
+		// Don't validate topics from within test suite
+		return nil
+	}
+
+	appList, err := m.Env.GetAppsInEnv(m.Ctx, m.Client)
+	if err != nil {
+		return errors.Wrap("Topic validation failed: Error listing apps", err)
+	}
+
+	clusters := []string{""}
+	for _, cluster := range m.clusters {
+		clusters = append(clusters, cluster.name)
+	}
+
+	for _, cluster := range clusters {
+		if err := validateTopicProducers(getClusterApp(app, cluster), getClusterAppList(appList, cluster)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package kafka
+
+import (
+	"testing"
+
+	crd "github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1alpha1"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/config"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers"
+	"github.com/stretchr/testify/assert"
+)
+
+func makeMultiClusterTestEnv() *crd.ClowdEnvironment {
+	return &crd.ClowdEnvironment{
+		Spec: crd.ClowdEnvironmentSpec{
+			Providers: crd.ProvidersConfig{
+				Kafka: crd.KafkaConfig{
+					Mode: "app-interface",
+					Cluster: crd.KafkaClusterConfig{
+						Name:      "platform-mq",
+						Namespace: "platform-mq-prod",
+					},
+					Connect: crd.KafkaConnectClusterConfig{Name: "platform-connect"},
+					Clusters: []crd.KafkaNamedClusterConfig{{
+						Name: "analytics",
+						Mode: "app-interface",
+						Cluster: crd.KafkaClusterConfig{
+							Namespace: "analytics-mq-prod",
+						},
+					}},
+				},
+			},
+		},
+	}
+}
+
+func TestGetClusterEnv(t *testing.T) {
+	env := makeMultiClusterTestEnv()
+
+	clusterEnv := getClusterEnv(env, env.Spec.Providers.Kafka.Clusters[0])
+	kafka := clusterEnv.Spec.Providers.Kafka
+	assert.Equal(t, crd.KafkaMode("app-interface"), kafka.Mode)
+	assert.Equal(t, "platform-mq-analytics", kafka.Cluster.Name)
+	assert.Equal(t, "analytics-mq-prod", kafka.Cluster.Namespace)
+	assert.Empty(t, kafka.Connect.Name)
+	assert.Nil(t, kafka.Clusters)
+
+	assert.Equal(t, "platform-mq", env.Spec.Providers.Kafka.Cluster.Name)
+	assert.Len(t, env.Spec.Providers.Kafka.Clusters, 1)
+}
+
+func TestValidateTopicClusters(t *testing.T) {
+	env := makeMultiClusterTestEnv()
+
+	app := makeRoleTestApp("app",
+		crd.KafkaTopicSpec{TopicName: "events"},
+		crd.KafkaTopicSpec{TopicName: "clicks", Cluster: "analytics"},
+	)
+	assert.NoError(t, validateTopicClusters(env, &app))
+	assert.Equal(t, crd.KafkaMode("app-interface"), GetClusterMode(env, "analytics"))
+
+	app.Spec.KafkaTopics[1].Cluster = "billing"
+	assert.Error(t, validateTopicClusters(env, &app))
+	assert.Empty(t, GetClusterMode(env, "billing"))
+}
+
+func TestMultiCluster(t *testing.T) {
+	pr := providers.Provider{
+		Env:    makeMultiClusterTestEnv(),
+		Config: &config.AppConfig{},
+	}
+
+	app := makeRoleTestApp("app",
+		crd.KafkaTopicSpec{TopicName: "events"},
+		crd.KafkaTopicSpec{TopicName: "clicks", Cluster: "analytics", DeadLetter: &crd.KafkaCompanionTopicSpec{}},
+	)
+
+	defaultProvider, err := NewAppInterface(&pr)
+	assert.NoError(t, err)
+
+	mc, err := NewMultiCluster(&pr, defaultProvider)
+	assert.NoError(t, err)
+
+	assert.NoError(t, mc.EnvProvide())
+	assert.NoError(t, mc.Provide(&app))
+
+	kafkaConfig := mc.GetConfig().Kafka
+	assert.Len(t, kafkaConfig.Brokers, 1, "wrong number of brokers")
+	assert.Equal(t, "platform-mq-kafka-bootstrap.platform-mq-prod.svc", kafkaConfig.Brokers[0].Hostname)
+
+	assert.Len(t, kafkaConfig.Topics, 3, "wrong number of topics")
+
+	topic := kafkaConfig.Topics[0]
+	assert.Equal(t, "events", topic.Name)
+	assert.Nil(t, topic.Cluster)
+	assert.Nil(t, topic.Brokers)
+
+	for _, topic := range kafkaConfig.Topics[1:] {
+		assert.Equal(t, "analytics", *topic.Cluster)
+		assert.Len(t, topic.Brokers, 1, "wrong number of topic brokers")
+		assert.Equal(t, "platform-mq-analytics-kafka-bootstrap.analytics-mq-prod.svc", topic.Brokers[0].Hostname)
+	}
+	assert.Equal(t, "clicks", kafkaConfig.Topics[1].Name)
+	assert.Equal(t, "clicks.dlq", *kafkaConfig.Topics[1].DeadLetterTopicName)
+	assert.Equal(t, "clicks.dlq", kafkaConfig.Topics[2].Name)
+
+	assert.Len(t, app.Spec.KafkaTopics, 2, "app spec was modified")
+}
@@ -34,6 +34,15 @@ var CyndiConfigMap = rc.NewSingleResourceIdent(ProvName, "cyndi_config_map", &co
 // GetKafka returns the correct kafka provider based on the environment.
 func GetKafka(c *providers.Provider) (providers.ClowderProvider, error) {
 	c.Env.ConvertDeprecatedKafkaSpec()
+	defaultProvider, err := getDefaultKafka(c)
+	if err != nil {
+		return nil, err
+	}
+	return NewMultiCluster(c, defaultProvider)
+}
+
+// getDefaultKafka returns the provider of the default Kafka cluster of the environment.
+func getDefaultKafka(c *providers.Provider) (providers.ClowderProvider, error) {
 	kafkaMode := c.Env.Spec.Providers.Kafka.Mode
 	switch kafkaMode {
 	case "operator":
@@ -95,4 +104,5 @@ func getConnectClusterUserName(env *crd.ClowdEnvironment) string {
 
 func init() {
 	providers.ProvidersRegistration.Register(GetKafka, 6, ProvName)
+	providers.ProvidersRegistration.RegisterAppFinalizer(ProvName)
 }
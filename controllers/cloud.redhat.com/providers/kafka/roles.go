@@ -0,0 +1,150 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	crd "github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1alpha1"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/config"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/errors"
+
+	"github.com/RedHatInsights/rhc-osdk-utils/utils"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// TopicRoleProducer is the role of an app which only produces to a topic.
+	TopicRoleProducer = "producer"
+	// TopicRoleConsumer is the role of an app which only consumes from a topic.
+	TopicRoleConsumer = "consumer"
+	// TopicRoleBoth is the role of an app which produces to and consumes from a
+	// topic, it is assumed when no role is declared.
+	TopicRoleBoth = "both"
+)
+
+func getTopicRole(topic crd.KafkaTopicSpec) string {
+	if topic.Role == "" {
+		return TopicRoleBoth
+	}
+	return topic.Role
+}
+
+func isTopicProducer(topic crd.KafkaTopicSpec) bool {
+	return getTopicRole(topic) != TopicRoleConsumer
+}
+
+func isTopicConsumer(topic crd.KafkaTopicSpec) bool {
+	return getTopicRole(topic) != TopicRoleProducer
+}
+
+// makeTopicConfig returns the config of a topic the app declared, under the
+// name it has on the Kafka server.
+func makeTopicConfig(name string, topic crd.KafkaTopicSpec) config.TopicConfig {
+	topicConfig := config.TopicConfig{
+		Name:          name,
+		RequestedName: topic.TopicName,
+	}
+	if topic.Role != "" {
+		role := topic.Role
+		topicConfig.Role = &role
+	}
+	if topic.ConsumerGroup != "" {
+		group := topic.ConsumerGroup
+		topicConfig.ConsumerGroupName = &group
+	}
+	return topicConfig
+}
+
+// getUnproducedTopics returns the topics the app consumes which no app in the
+// environment produces to.
+func getUnproducedTopics(app *crd.ClowdApp, appList *crd.ClowdAppList) []string {
+	produced := map[string]bool{}
+	for _, iapp := range appList.Items {
+		if iapp.GetDeletionTimestamp() != nil {
+			continue
+		}
+		for _, topic := range iapp.Spec.KafkaTopics {
+			if isTopicProducer(topic) {
+				produced[topic.TopicName] = true
+			}
+		}
+	}
+	for _, topic := range app.Spec.KafkaTopics {
+		if isTopicProducer(topic) {
+			produced[topic.TopicName] = true
+		}
+	}
+
+	missing := []string{}
+	for _, topic := range app.Spec.KafkaTopics {
+		if !produced[topic.TopicName] {
+			missing = append(missing, topic.TopicName)
+			produced[topic.TopicName] = true
+		}
+	}
+	return missing
+}
+
+func validateTopicProducers(app *crd.ClowdApp, appList *crd.ClowdAppList) error {
+	missing := getUnproducedTopics(app, appList)
+	if len(missing) == 0 {
+		return nil
+	}
+
+	missingDeps := errors.MakeMissingDependencies(errors.MissingDependency{
+		Source:  "kafka",
+		Details: fmt.Sprintf("No app produces the consumed topics [%s]", strings.Join(missing, ", ")),
+	})
+	return &missingDeps
+}
+
+// validateEnvTopicProducers checks that every topic the app consumes is
+// produced by an app in the environment.
+func validateEnvTopicProducers(ctx context.Context, cl client.Client, env *crd.ClowdEnvironment, app *crd.ClowdApp) error {
+	if cl == nil {
+		// Don't validate topics from within test suite
+		return nil
+	}
+
+	if len(app.Spec.KafkaTopics) == 0 {
+		return nil
+	}
+
+	appList, err := env.GetAppsInEnv(ctx, cl)
+	if err != nil {
+		return errors.Wrap("Topic validation failed: Error listing apps", err)
+	}
+
+	return validateTopicProducers(app, appList)
+}
+
+// GetTopicOwnership returns the apps producing to and consuming from each topic
+// requested by the apps in the list, keyed by the requested topic name.
+func GetTopicOwnership(appList *crd.ClowdAppList) map[string]crd.TopicOwnership {
+	ownership := map[string]crd.TopicOwnership{}
+
+	for _, app := range appList.Items {
+		if app.GetDeletionTimestamp() != nil {
+			continue
+		}
+		for _, topic := range getAppTopics(&app) {
+			owners := ownership[topic.TopicName]
+			if isTopicProducer(topic) && !utils.Contains(owners.Producers, app.Name) {
+				owners.Producers = append(owners.Producers, app.Name)
+			}
+			if isTopicConsumer(topic) && !utils.Contains(owners.Consumers, app.Name) {
+				owners.Consumers = append(owners.Consumers, app.Name)
+			}
+			ownership[topic.TopicName] = owners
+		}
+	}
+
+	for _, owners := range ownership {
+		sort.Strings(owners.Producers)
+		sort.Strings(owners.Consumers)
+	}
+
+	return ownership
+}
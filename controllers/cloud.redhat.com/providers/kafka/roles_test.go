@@ -0,0 +1,68 @@
+package kafka
+
+import (
+	"testing"
+
+	crd "github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func makeRoleTestApp(name string, topics ...crd.KafkaTopicSpec) crd.ClowdApp {
+	return crd.ClowdApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test",
+		},
+		Spec: crd.ClowdAppSpec{
+			KafkaTopics: topics,
+		},
+	}
+}
+
+func TestMakeTopicConfig(t *testing.T) {
+	topicConfig := makeTopicConfig("events-env", crd.KafkaTopicSpec{
+		TopicName:     "events",
+		Role:          TopicRoleConsumer,
+		ConsumerGroup: "myapp-events",
+	})
+	assert.Equal(t, "events-env", topicConfig.Name)
+	assert.Equal(t, "events", topicConfig.RequestedName)
+	assert.Equal(t, TopicRoleConsumer, *topicConfig.Role)
+	assert.Equal(t, "myapp-events", *topicConfig.ConsumerGroupName)
+
+	topicConfig = makeTopicConfig("events", crd.KafkaTopicSpec{TopicName: "events"})
+	assert.Nil(t, topicConfig.Role)
+	assert.Nil(t, topicConfig.ConsumerGroupName)
+}
+
+func TestTopicProducers(t *testing.T) {
+	producer := makeRoleTestApp("producer",
+		crd.KafkaTopicSpec{TopicName: "events", Role: TopicRoleProducer},
+		crd.KafkaTopicSpec{TopicName: "legacy"},
+	)
+	consumer := makeRoleTestApp("consumer",
+		crd.KafkaTopicSpec{TopicName: "events", Role: TopicRoleConsumer, ConsumerGroup: "consumer-events"},
+		crd.KafkaTopicSpec{TopicName: "legacy", Role: TopicRoleConsumer},
+		crd.KafkaTopicSpec{TopicName: "orphan", Role: TopicRoleConsumer},
+	)
+	appList := &crd.ClowdAppList{Items: []crd.ClowdApp{producer, consumer}}
+
+	assert.Equal(t, []string{"orphan"}, getUnproducedTopics(&consumer, appList))
+	assert.Empty(t, getUnproducedTopics(&producer, appList))
+	assert.Error(t, validateTopicProducers(&consumer, appList))
+	assert.NoError(t, validateTopicProducers(&producer, appList))
+
+	ownership := GetTopicOwnership(appList)
+	assert.Equal(t, crd.TopicOwnership{
+		Producers: []string{"producer"},
+		Consumers: []string{"consumer"},
+	}, ownership["events"])
+	assert.Equal(t, crd.TopicOwnership{
+		Producers: []string{"producer"},
+		Consumers: []string{"consumer", "producer"},
+	}, ownership["legacy"])
+	assert.Equal(t, crd.TopicOwnership{
+		Consumers: []string{"consumer"},
+	}, ownership["orphan"])
+}
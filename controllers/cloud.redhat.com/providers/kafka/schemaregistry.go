@@ -0,0 +1,310 @@
+package kafka
+
+import (
+	"fmt"
+
+	crd "github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1alpha1"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/config"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/errors"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers"
+	strimzi "github.com/RedHatInsights/strimzi-client-go/apis/kafka.strimzi.io/v1beta2"
+
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	rc "github.com/RedHatInsights/rhc-osdk-utils/resourceCache"
+	"github.com/RedHatInsights/rhc-osdk-utils/utils"
+)
+
+var DefaultImageSchemaRegistry = "docker.io/confluentinc/cp-schema-registry:7.4.0"
+
+// SchemaRegistryPort is the port the local schema registry listens on.
+const SchemaRegistryPort = 8081
+
+// schemaRegistryTopic is the topic the local schema registry stores schemas in.
+const schemaRegistryTopic = "_schemas"
+
+// KafkaSchemaRegistryUser is the resource ident for the KafkaUser of the local schema registry.
+var KafkaSchemaRegistryUser = rc.NewSingleResourceIdent(ProvName, "kafka_schema_registry_user", &strimzi.KafkaUser{}, rc.ResourceOptions{WriteNow: true})
+
+// KafkaSchemaRegistryDeployment is the resource ident for the deployment of the local schema registry.
+var KafkaSchemaRegistryDeployment = rc.NewSingleResourceIdent(ProvName, "kafka_schema_registry_deployment", &apps.Deployment{}, rc.ResourceOptions{WriteNow: true})
+
+// KafkaSchemaRegistryService is the resource ident for the service of the local schema registry.
+var KafkaSchemaRegistryService = rc.NewSingleResourceIdent(ProvName, "kafka_schema_registry_service", &core.Service{}, rc.ResourceOptions{WriteNow: true})
+
+func getSchemaRegistryNamespacedName(env *crd.ClowdEnvironment) types.NamespacedName {
+	return types.NamespacedName{
+		Name:      fmt.Sprintf("%s-schema-registry", getKafkaName(env)),
+		Namespace: getKafkaNamespace(env),
+	}
+}
+
+func getSchemaRegistryUsername(env *crd.ClowdEnvironment) string {
+	return fmt.Sprintf("%s-schema-registry", env.Name)
+}
+
+func getSchemaRegistryImage(env *crd.ClowdEnvironment) string {
+	if env.Spec.Providers.Kafka.SchemaRegistry.Image != "" {
+		return env.Spec.Providers.Kafka.SchemaRegistry.Image
+	}
+	return DefaultImageSchemaRegistry
+}
+
+// getSchemaRegistryAcls returns the ACLs the local schema registry needs to
+// store schemas in its topic.
+func getSchemaRegistryAcls() []strimzi.KafkaUserSpecAuthorizationAclsElem {
+	literal := strimzi.KafkaUserSpecAuthorizationAclsElemResourcePatternTypeLiteral
+	prefix := strimzi.KafkaUserSpecAuthorizationAclsElemResourcePatternTypePrefix
+	all := []strimzi.KafkaUserSpecAuthorizationAclsElemOperationsElem{
+		strimzi.KafkaUserSpecAuthorizationAclsElemOperationsElemAll,
+	}
+
+	return []strimzi.KafkaUserSpecAuthorizationAclsElem{
+		makeAcl(schemaRegistryTopic, strimzi.KafkaUserSpecAuthorizationAclsElemResourceTypeTopic, literal, all),
+		makeAcl("schema-registry", strimzi.KafkaUserSpecAuthorizationAclsElemResourceTypeGroup, prefix, all),
+	}
+}
+
+func (s *strimziProvider) createSchemaRegistryUser() error {
+	nn := types.NamespacedName{
+		Name:      getSchemaRegistryUsername(s.Env),
+		Namespace: getKafkaNamespace(s.Env),
+	}
+
+	ku := &strimzi.KafkaUser{}
+	if err := s.Cache.Create(KafkaSchemaRegistryUser, nn, ku); err != nil {
+		return err
+	}
+
+	labeler := utils.GetCustomLabeler(
+		map[string]string{"strimzi.io/cluster": getKafkaName(s.Env)}, nn, s.Env,
+	)
+	labeler(ku)
+
+	ku.Spec = &strimzi.KafkaUserSpec{
+		Authentication: &strimzi.KafkaUserSpecAuthentication{
+			Type: strimzi.KafkaUserSpecAuthenticationTypeScramSha512,
+		},
+		Authorization: &strimzi.KafkaUserSpecAuthorization{
+			Acls: getSchemaRegistryAcls(),
+			Type: strimzi.KafkaUserSpecAuthorizationTypeSimple,
+		},
+	}
+
+	return s.Cache.Update(KafkaSchemaRegistryUser, ku)
+}
+
+// makeSchemaRegistryEnv returns the environment of the local schema registry,
+// connecting it to the brokers of the Kafka cluster.
+func makeSchemaRegistryEnv(env *crd.ClowdEnvironment, nn types.NamespacedName, bootstrapServers string) []core.EnvVar {
+	envVars := []core.EnvVar{
+		{Name: "SCHEMA_REGISTRY_HOST_NAME", Value: fmt.Sprintf("%s.%s.svc", nn.Name, nn.Namespace)},
+		{Name: "SCHEMA_REGISTRY_LISTENERS", Value: fmt.Sprintf("http://0.0.0.0:%d", SchemaRegistryPort)},
+		{Name: "SCHEMA_REGISTRY_KAFKASTORE_BOOTSTRAP_SERVERS", Value: bootstrapServers},
+		{Name: "SCHEMA_REGISTRY_KAFKASTORE_TOPIC", Value: schemaRegistryTopic},
+		{Name: "SCHEMA_REGISTRY_KAFKASTORE_TOPIC_REPLICATION_FACTOR", Value: "1"},
+	}
+
+	if env.Spec.Providers.Kafka.EnableLegacyStrimzi {
+		return append(envVars, core.EnvVar{Name: "SCHEMA_REGISTRY_KAFKASTORE_SECURITY_PROTOCOL", Value: "PLAINTEXT"})
+	}
+
+	caSecretName := fmt.Sprintf("%s-cluster-ca-cert", getKafkaName(env))
+
+	return append(envVars,
+		core.EnvVar{Name: "SCHEMA_REGISTRY_KAFKASTORE_SECURITY_PROTOCOL", Value: "SASL_SSL"},
+		core.EnvVar{Name: "SCHEMA_REGISTRY_KAFKASTORE_SASL_MECHANISM", Value: "SCRAM-SHA-512"},
+		core.EnvVar{
+			Name: "SCHEMA_REGISTRY_KAFKASTORE_SASL_JAAS_CONFIG",
+			ValueFrom: &core.EnvVarSource{
+				SecretKeyRef: &core.SecretKeySelector{
+					LocalObjectReference: core.LocalObjectReference{Name: getSchemaRegistryUsername(env)},
+					Key:                  "sasl.jaas.config",
+				},
+			},
+		},
+		core.EnvVar{Name: "SCHEMA_REGISTRY_KAFKASTORE_SSL_TRUSTSTORE_LOCATION", Value: "/etc/kafka-ca/ca.p12"},
+		core.EnvVar{Name: "SCHEMA_REGISTRY_KAFKASTORE_SSL_TRUSTSTORE_TYPE", Value: "PKCS12"},
+		core.EnvVar{
+			Name: "SCHEMA_REGISTRY_KAFKASTORE_SSL_TRUSTSTORE_PASSWORD",
+			ValueFrom: &core.EnvVarSource{
+				SecretKeyRef: &core.SecretKeySelector{
+					LocalObjectReference: core.LocalObjectReference{Name: caSecretName},
+					Key:                  "ca.password",
+				},
+			},
+		},
+	)
+}
+
+// configureSchemaRegistry deploys a schema registry next to the Kafka cluster,
+// storing its schemas in the cluster, when the environment asks for a local one.
+func (s *strimziProvider) configureSchemaRegistry(configs *config.KafkaConfig) error {
+	if s.Env.Spec.Providers.Kafka.SchemaRegistry.Mode != "local" {
+		return nil
+	}
+
+	if !s.Env.Spec.Providers.Kafka.EnableLegacyStrimzi {
+		if err := s.createSchemaRegistryUser(); err != nil {
+			return err
+		}
+	}
+
+	nn := getSchemaRegistryNamespacedName(s.Env)
+	labels := map[string]string{"env": s.Env.Name, "service": "schema-registry"}
+	labeler := utils.GetCustomLabeler(labels, nn, s.Env)
+
+	dd := &apps.Deployment{}
+	if err := s.Cache.Create(KafkaSchemaRegistryDeployment, nn, dd); err != nil {
+		return err
+	}
+
+	labeler(dd)
+
+	probe := &core.Probe{
+		ProbeHandler: core.ProbeHandler{
+			HTTPGet: &core.HTTPGetAction{
+				Path:   "/subjects",
+				Port:   intstr.FromInt(SchemaRegistryPort),
+				Scheme: core.URISchemeHTTP,
+			},
+		},
+		InitialDelaySeconds: 15,
+		TimeoutSeconds:      2,
+		PeriodSeconds:       10,
+		SuccessThreshold:    1,
+		FailureThreshold:    3,
+	}
+
+	dd.Spec.Replicas = utils.Int32Ptr(1)
+	dd.Spec.Selector = &metav1.LabelSelector{MatchLabels: labels}
+	dd.Spec.Template.ObjectMeta.Labels = labels
+	dd.Spec.Template.Spec.Volumes = []core.Volume{}
+	volumeMounts := []core.VolumeMount{}
+
+	if !s.Env.Spec.Providers.Kafka.EnableLegacyStrimzi {
+		dd.Spec.Template.Spec.Volumes = append(dd.Spec.Template.Spec.Volumes, core.Volume{
+			Name: "kafka-ca",
+			VolumeSource: core.VolumeSource{
+				Secret: &core.SecretVolumeSource{
+					SecretName: fmt.Sprintf("%s-cluster-ca-cert", getKafkaName(s.Env)),
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, core.VolumeMount{
+			Name:      "kafka-ca",
+			MountPath: "/etc/kafka-ca",
+			ReadOnly:  true,
+		})
+	}
+
+	dd.Spec.Template.Spec.Containers = []core.Container{{
+		Name:  "schema-registry",
+		Image: getSchemaRegistryImage(s.Env),
+		Env:   makeSchemaRegistryEnv(s.Env, nn, s.getBootstrapServersString(configs)),
+		Ports: []core.ContainerPort{{
+			Name:          "registry",
+			ContainerPort: SchemaRegistryPort,
+			Protocol:      core.ProtocolTCP,
+		}},
+		VolumeMounts:   volumeMounts,
+		LivenessProbe:  probe,
+		ReadinessProbe: probe,
+		Resources: core.ResourceRequirements{
+			Requests: core.ResourceList{
+				core.ResourceCPU:    resource.MustParse("100m"),
+				core.ResourceMemory: resource.MustParse("256Mi"),
+			},
+			Limits: core.ResourceList{
+				core.ResourceCPU:    resource.MustParse("500m"),
+				core.ResourceMemory: resource.MustParse("512Mi"),
+			},
+		},
+		TerminationMessagePath:   "/dev/termination-log",
+		TerminationMessagePolicy: core.TerminationMessageReadFile,
+		ImagePullPolicy:          core.PullIfNotPresent,
+	}}
+
+	if err := s.Cache.Update(KafkaSchemaRegistryDeployment, dd); err != nil {
+		return err
+	}
+
+	svc := &core.Service{}
+	if err := s.Cache.Create(KafkaSchemaRegistryService, nn, svc); err != nil {
+		return err
+	}
+
+	servicePorts := []core.ServicePort{{
+		Name:       "registry",
+		Port:       SchemaRegistryPort,
+		Protocol:   core.ProtocolTCP,
+		TargetPort: intstr.FromInt(SchemaRegistryPort),
+	}}
+	utils.MakeService(svc, nn, labels, servicePorts, s.Env, false)
+
+	return s.Cache.Update(KafkaSchemaRegistryService, svc)
+}
+
+// hasSchemaRegistry returns whether the environment gives its apps a schema registry.
+func hasSchemaRegistry(env *crd.ClowdEnvironment) bool {
+	mode := env.Spec.Providers.Kafka.SchemaRegistry.Mode
+	return mode != "" && mode != "none"
+}
+
+// setSchemaRegistryConfig gives the app the schema registry of the environment,
+// if it has one.
+func setSchemaRegistryConfig(p *providers.Provider, c *config.KafkaConfig) error {
+	registry := p.Env.Spec.Providers.Kafka.SchemaRegistry
+
+	switch registry.Mode {
+	case "local":
+		if p.Env.Spec.Providers.Kafka.Mode != "operator" {
+			return errors.NewClowderError("a local schema registry is only supported in operator mode")
+		}
+		nn := getSchemaRegistryNamespacedName(p.Env)
+		c.SchemaRegistry = &config.SchemaRegistryConfig{
+			Url: fmt.Sprintf("http://%s.%s.svc:%d", nn.Name, nn.Namespace, SchemaRegistryPort),
+		}
+	case "app-interface":
+		nn := types.NamespacedName{
+			Name:      registry.SecretRef.Name,
+			Namespace: registry.SecretRef.Namespace,
+		}
+		secret := &core.Secret{}
+		if err := p.Client.Get(p.Ctx, nn, secret); err != nil {
+			missingDeps := errors.MakeMissingDependencies(errors.MissingDependency{
+				Source:  "kafka",
+				Details: fmt.Sprintf("No schema registry secret named '%s' found in namespace '%s'", nn.Name, nn.Namespace),
+			})
+			return &missingDeps
+		}
+		registryConfig, err := getSchemaRegistryConfigFromSecret(secret)
+		if err != nil {
+			return err
+		}
+		c.SchemaRegistry = registryConfig
+	}
+
+	return nil
+}
+
+func getSchemaRegistryConfigFromSecret(secret *core.Secret) (*config.SchemaRegistryConfig, error) {
+	url := string(secret.Data["url"])
+	if url == "" {
+		return nil, errors.NewClowderError(fmt.Sprintf("no url in schema registry secret [%s]", secret.Name))
+	}
+
+	registryConfig := &config.SchemaRegistryConfig{Url: url}
+	if username, ok := secret.Data["username"]; ok {
+		registryConfig.Username = utils.StringPtr(string(username))
+	}
+	if password, ok := secret.Data["password"]; ok {
+		registryConfig.Password = utils.StringPtr(string(password))
+	}
+	return registryConfig, nil
+}
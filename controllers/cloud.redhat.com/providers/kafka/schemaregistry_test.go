@@ -0,0 +1,92 @@
+package kafka
+
+import (
+	"testing"
+
+	crd "github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1alpha1"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/config"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers"
+	"github.com/stretchr/testify/assert"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func makeSchemaRegistryTestEnv(kafkaMode crd.KafkaMode, registryMode crd.SchemaRegistryMode) *crd.ClowdEnvironment {
+	return &crd.ClowdEnvironment{
+		ObjectMeta: metav1.ObjectMeta{Name: "env"},
+		Spec: crd.ClowdEnvironmentSpec{
+			Providers: crd.ProvidersConfig{
+				Kafka: crd.KafkaConfig{
+					Mode: kafkaMode,
+					Cluster: crd.KafkaClusterConfig{
+						Name:      "kafka",
+						Namespace: "kafka-ns",
+					},
+					SchemaRegistry: crd.SchemaRegistryConfig{Mode: registryMode},
+				},
+			},
+		},
+	}
+}
+
+func TestSchemaRegistryLocal(t *testing.T) {
+	p := &providers.Provider{Env: makeSchemaRegistryTestEnv("operator", "local")}
+	c := &config.KafkaConfig{}
+
+	assert.NoError(t, setSchemaRegistryConfig(p, c))
+	assert.Equal(t, "http://kafka-schema-registry.kafka-ns.svc:8081", c.SchemaRegistry.Url)
+	assert.Nil(t, c.SchemaRegistry.Username)
+
+	p.Env.Spec.Providers.Kafka.Mode = "managed"
+	assert.Error(t, setSchemaRegistryConfig(p, &config.KafkaConfig{}))
+}
+
+func TestSchemaRegistryNone(t *testing.T) {
+	p := &providers.Provider{Env: makeSchemaRegistryTestEnv("operator", "")}
+	c := &config.KafkaConfig{}
+
+	assert.NoError(t, setSchemaRegistryConfig(p, c))
+	assert.Nil(t, c.SchemaRegistry)
+}
+
+func TestSchemaRegistrySecret(t *testing.T) {
+	secret := &core.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "registry"},
+		Data: map[string][]byte{
+			"url":      []byte("https://registry.example.com"),
+			"username": []byte("user"),
+			"password": []byte("pass"),
+		},
+	}
+
+	registryConfig, err := getSchemaRegistryConfigFromSecret(secret)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://registry.example.com", registryConfig.Url)
+	assert.Equal(t, "user", *registryConfig.Username)
+	assert.Equal(t, "pass", *registryConfig.Password)
+
+	delete(secret.Data, "url")
+	_, err = getSchemaRegistryConfigFromSecret(secret)
+	assert.Error(t, err)
+}
+
+func TestSchemaRegistryEnv(t *testing.T) {
+	env := makeSchemaRegistryTestEnv("operator", "local")
+	nn := getSchemaRegistryNamespacedName(env)
+
+	envVars := map[string]core.EnvVar{}
+	for _, envVar := range makeSchemaRegistryEnv(env, nn, "kafka-kafka-bootstrap.kafka-ns.svc:9093") {
+		envVars[envVar.Name] = envVar
+	}
+	assert.Equal(t, "SASL_SSL", envVars["SCHEMA_REGISTRY_KAFKASTORE_SECURITY_PROTOCOL"].Value)
+	assert.Equal(t, "env-schema-registry", envVars["SCHEMA_REGISTRY_KAFKASTORE_SASL_JAAS_CONFIG"].ValueFrom.SecretKeyRef.Name)
+	assert.Equal(t, "kafka-kafka-bootstrap.kafka-ns.svc:9093", envVars["SCHEMA_REGISTRY_KAFKASTORE_BOOTSTRAP_SERVERS"].Value)
+
+	env.Spec.Providers.Kafka.EnableLegacyStrimzi = true
+	envVars = map[string]core.EnvVar{}
+	for _, envVar := range makeSchemaRegistryEnv(env, nn, "kafka-kafka-bootstrap.kafka-ns.svc:9092") {
+		envVars[envVar.Name] = envVar
+	}
+	assert.Equal(t, "PLAINTEXT", envVars["SCHEMA_REGISTRY_KAFKASTORE_SECURITY_PROTOCOL"].Value)
+	assert.NotContains(t, envVars, "SCHEMA_REGISTRY_KAFKASTORE_SASL_JAAS_CONFIG")
+}
@@ -47,6 +47,10 @@ var KafkaNetworkPolicy = rc.NewSingleResourceIdent(ProvName, "kafka_network_poli
 
 type strimziProvider struct {
 	providers.Provider
+
+	// The name of the named Kafka cluster of the environment the provider is for, empty for the
+	// default cluster.
+	cluster string
 }
 
 // NewStrimzi returns a new strimzi provider object.
@@ -63,18 +67,68 @@ func NewStrimzi(p *providers.Provider) (providers.ClowderProvider, error) {
 		KafkaConnectUser,
 		KafkaMetricsConfigMap,
 		KafkaNetworkPolicy,
+		KafkaSchemaRegistryUser,
+		KafkaSchemaRegistryDeployment,
+		KafkaSchemaRegistryService,
+		KafkaConnector,
 	)
 	return &strimziProvider{Provider: *p}, nil
 }
 
+// newClusterStrimzi returns a strimzi provider object for a named Kafka cluster of the
+// environment, configured in the environment it is given.
+func newClusterStrimzi(p *providers.Provider, cluster string) (providers.ClowderProvider, error) {
+	prov, err := NewStrimzi(p)
+	if err != nil {
+		return nil, err
+	}
+	prov.(*strimziProvider).cluster = cluster
+	return prov, nil
+}
+
+// getAppsInCluster returns the apps of the environment, with only the topics they place on the
+// Kafka cluster of the provider.
+func (s *strimziProvider) getAppsInCluster() (*crd.ClowdAppList, error) {
+	appList, err := s.Env.GetAppsInEnv(s.Ctx, s.Client)
+	if err != nil {
+		return nil, err
+	}
+	return getClusterAppList(appList, s.cluster), nil
+}
+
+func (s *strimziProvider) getKafkaUsername(app *crd.ClowdApp) string {
+	if s.cluster == "" {
+		return getKafkaUsername(s.Env, app)
+	}
+	return fmt.Sprintf("%s-%s", getKafkaUsername(s.Env, app), s.cluster)
+}
+
 func (s *strimziProvider) EnvProvide() error {
 	if err := createNetworkPolicies(&s.Provider); err != nil {
 		return err
 	}
 
-	return s.configureBrokers()
+	if err := s.configureBrokers(); err != nil {
+		return err
+	}
+
+	return s.cleanupTopics()
 }
 
+// FinalizeApp deletes the KafkaConnectors of a deleted app and applies the deletion policy of
+// the topics no other app declares.
+func (s *strimziProvider) FinalizeApp(app *crd.ClowdApp) error {
+	if s.cluster == "" {
+		if err := s.deleteConnectors(app, map[string]bool{}); err != nil {
+			return err
+		}
+	}
+
+	return s.cleanupTopics()
+}
+
+var _ providers.AppFinalizer = &strimziProvider{}
+
 func (s *strimziProvider) Provide(app *crd.ClowdApp) error {
 	clusterNN := types.NamespacedName{
 		Namespace: getKafkaNamespace(s.Env),
@@ -108,6 +162,11 @@ func (s *strimziProvider) Provide(app *crd.ClowdApp) error {
 		}
 	}
 
+	// The schema registry is given to every app, whether it declares topics or not
+	if err := setSchemaRegistryConfig(&s.Provider, s.Config.Kafka); err != nil {
+		return err
+	}
+
 	if app.Spec.Cyndi.Enabled {
 		err := createCyndiPipeline(s, app, getConnectNamespace(s.Env), getConnectClusterName(s.Env))
 		if err != nil {
@@ -115,25 +174,37 @@ func (s *strimziProvider) Provide(app *crd.ClowdApp) error {
 		}
 	}
 
-	if len(app.Spec.KafkaTopics) == 0 {
-		return nil
+	appList, err := s.getAppsInCluster()
+	if err != nil {
+		return errors.Wrap("Topic creation failed: Error listing apps", err)
+	}
+
+	// Apps without topics of their own still need a user to read the topics
+	// of the apps they depend on
+	if len(app.Spec.KafkaTopics) == 0 && len(getDependencyTopicNames(s.Env, app, appList)) == 0 {
+		return s.processConnectors(app)
+	}
+
+	if err := validateTopicProducers(app, appList); err != nil {
+		return err
 	}
 
-	if err := s.processTopics(app, s.Config.Kafka); err != nil {
+	if err := s.processTopics(app, s.Config.Kafka, appList); err != nil {
 		return err
 	}
 
 	if !s.Env.Spec.Providers.Kafka.EnableLegacyStrimzi {
-		if err := s.createKafkaUser(app); err != nil {
+		if err := s.createKafkaUser(app, appList); err != nil {
 			return err
 		}
+		s.Config.Kafka.ConsumerGroupPrefix = utils.StringPtr(getConsumerGroupPrefix(app))
 
 		if err := s.setBrokerCredentials(app, s.Config.Kafka); err != nil {
 			return err
 		}
 	}
 
-	return nil
+	return s.processConnectors(app)
 }
 
 var conversionMap = map[string]func([]string) (string, error){
@@ -758,10 +829,19 @@ func (s *strimziProvider) configureBrokers() error {
 		return clowdErr
 	}
 
+	// Kafka Connect and the schema registry only run along with the default cluster
+	if s.cluster != "" {
+		return nil
+	}
+
 	if err := s.configureKafkaConnectCluster(config); err != nil {
 		return errors.Wrap("failed to provision kafka connect cluster", err)
 	}
 
+	if err := s.configureSchemaRegistry(config); err != nil {
+		return errors.Wrap("failed to provision schema registry", err)
+	}
+
 	return nil
 }
 
@@ -820,7 +900,7 @@ func (s *strimziProvider) setBrokerCredentials(app *crd.ClowdApp, configs *confi
 		if *broker.Authtype == config.BrokerConfigAuthtypeSasl {
 			ku := &strimzi.KafkaUser{}
 			nn := types.NamespacedName{
-				Name:      getKafkaUsername(s.Env, app),
+				Name:      s.getKafkaUsername(app),
 				Namespace: getKafkaNamespace(s.Env),
 			}
 
@@ -863,11 +943,10 @@ func (s *strimziProvider) setBrokerCredentials(app *crd.ClowdApp, configs *confi
 	return nil
 }
 
-func (s *strimziProvider) createKafkaUser(app *crd.ClowdApp) error {
-
+func (s *strimziProvider) createKafkaUser(app *crd.ClowdApp, appList *crd.ClowdAppList) error {
 	ku := &strimzi.KafkaUser{}
 	nn := types.NamespacedName{
-		Name:      getKafkaUsername(s.Env, app),
+		Name:      s.getKafkaUsername(app),
 		Namespace: getKafkaNamespace(s.Env),
 	}
 
@@ -885,53 +964,19 @@ func (s *strimziProvider) createKafkaUser(app *crd.ClowdApp) error {
 			Type: strimzi.KafkaUserSpecAuthenticationTypeScramSha512,
 		},
 		Authorization: &strimzi.KafkaUserSpecAuthorization{
-			Acls: []strimzi.KafkaUserSpecAuthorizationAclsElem{},
+			Acls: getKafkaUserAcls(s.Env, app, appList),
 			Type: strimzi.KafkaUserSpecAuthorizationTypeSimple,
 		},
 	}
 
-	address := "*"
-	patternType := strimzi.KafkaUserSpecAuthorizationAclsElemResourcePatternTypeLiteral
-	all := strimzi.KafkaUserSpecAuthorizationAclsElemOperationAll
-
-	for _, topic := range app.Spec.KafkaTopics {
-		topicName := getTopicName(topic, *s.Env, app.Namespace)
-
-		ku.Spec.Authorization.Acls = append(ku.Spec.Authorization.Acls, strimzi.KafkaUserSpecAuthorizationAclsElem{
-			Host:      &address,
-			Operation: &all,
-			Resource: strimzi.KafkaUserSpecAuthorizationAclsElemResource{
-				Name:        &topicName,
-				PatternType: &patternType,
-				Type:        strimzi.KafkaUserSpecAuthorizationAclsElemResourceTypeTopic,
-			},
-		})
-	}
-
-	group := "*"
-	ku.Spec.Authorization.Acls = append(ku.Spec.Authorization.Acls, strimzi.KafkaUserSpecAuthorizationAclsElem{
-		Host:      &address,
-		Operation: &all,
-		Resource: strimzi.KafkaUserSpecAuthorizationAclsElemResource{
-			Name:        &group,
-			PatternType: &patternType,
-			Type:        strimzi.KafkaUserSpecAuthorizationAclsElemResourceTypeGroup,
-		},
-	})
-
 	return s.Cache.Update(KafkaUser, ku)
 }
 
-func (s *strimziProvider) processTopics(app *crd.ClowdApp, c *config.KafkaConfig) error {
+func (s *strimziProvider) processTopics(app *crd.ClowdApp, c *config.KafkaConfig, appList *crd.ClowdAppList) error {
 	topicConfig := []config.TopicConfig{}
+	owners := getTopicOwners(s.Env, appList)
 
-	appList, err := s.Env.GetAppsInEnv(s.Ctx, s.Client)
-
-	if err != nil {
-		return errors.Wrap("Topic creation failed: Error listing apps", err)
-	}
-
-	for _, topic := range app.Spec.KafkaTopics {
+	for _, topic := range getAppTopics(app) {
 		k := &strimzi.KafkaTopic{}
 
 		topicName := getTopicName(topic, *s.Env, app.Namespace)
@@ -947,8 +992,6 @@ func (s *strimziProvider) processTopics(app *crd.ClowdApp, c *config.KafkaConfig
 		labels := providers.Labels{
 			"strimzi.io/cluster": getKafkaName(s.Env),
 			"env":                app.Spec.EnvName,
-			// If we label it with the app name, since app names should be
-			// unique? can we use for delete selector?
 		}
 
 		k.SetName(topicName)
@@ -957,6 +1000,14 @@ func (s *strimziProvider) processTopics(app *crd.ClowdApp, c *config.KafkaConfig
 		k.SetOwnerReferences([]metav1.OwnerReference{s.Env.MakeOwnerReference()})
 		k.SetLabels(labels)
 
+		// the apps declaring the topic are labelled so that it can be cleaned up
+		// once none of them is left
+		topicOwners := owners[topicName]
+		if !utils.Contains(topicOwners, app.Name) {
+			topicOwners = append(topicOwners, app.Name)
+		}
+		setTopicOwners(k, topicOwners, getDeclaredTopicDeletionPolicy(s.Env, appList, topicName))
+
 		k.Spec = &strimzi.KafkaTopicSpec{}
 
 		err := processTopicValues(k, s.Env, appList, topic)
@@ -971,10 +1022,11 @@ func (s *strimziProvider) processTopics(app *crd.ClowdApp, c *config.KafkaConfig
 
 		topicConfig = append(
 			topicConfig,
-			config.TopicConfig{Name: topicName, RequestedName: topic.TopicName},
+			makeTopicConfig(topicName, topic),
 		)
 	}
 
+	linkCompanionTopics(app, topicConfig)
 	c.Topics = topicConfig
 
 	return nil
@@ -1000,7 +1052,7 @@ func processTopicValues(
 
 	for _, iapp := range appList.Items {
 		if iapp.Spec.KafkaTopics != nil {
-			for _, itopic := range iapp.Spec.KafkaTopics {
+			for _, itopic := range getAppTopics(&iapp) {
 				if itopic.TopicName != topic.TopicName {
 					// Only consider a topic that matches the name
 					continue
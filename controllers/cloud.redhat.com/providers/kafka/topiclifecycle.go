@@ -0,0 +1,270 @@
+package kafka
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	crd "github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1alpha1"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/errors"
+	strimzi "github.com/RedHatInsights/strimzi-client-go/apis/kafka.strimzi.io/v1beta2"
+
+	"github.com/RedHatInsights/rhc-osdk-utils/utils"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// TopicDeletionPolicyRetain keeps a topic no app declares until the environment is removed.
+	TopicDeletionPolicyRetain crd.TopicDeletionPolicy = "retain"
+	// TopicDeletionPolicyDelete deletes a topic as soon as no app declares it.
+	TopicDeletionPolicyDelete crd.TopicDeletionPolicy = "delete"
+	// TopicDeletionPolicyGracePeriod deletes a topic once no app has declared it for the grace
+	// period of the environment.
+	TopicDeletionPolicyGracePeriod crd.TopicDeletionPolicy = "delete-after-grace-period"
+)
+
+// DefaultTopicDeletionGracePeriod is how long a topic no app declares is kept with the
+// delete-after-grace-period policy, when the environment does not set one.
+var DefaultTopicDeletionGracePeriod = 24 * time.Hour
+
+// topicOwnerLabelPrefix prefixes the labels naming the apps which declare a KafkaTopic.
+const topicOwnerLabelPrefix = "owner.kafka.cloud.redhat.com/"
+
+// topicDeletionPolicyAnnotation holds the deletion policy declared by the apps of a KafkaTopic.
+const topicDeletionPolicyAnnotation = "kafka.cloud.redhat.com/deletion-policy"
+
+// topicOrphanedAtAnnotation holds the time since which no app declares a KafkaTopic.
+const topicOrphanedAtAnnotation = "kafka.cloud.redhat.com/orphaned-at"
+
+// topicDeletionPolicyOrder orders the deletion policies from the one keeping a topic the
+// longest to the one keeping it the shortest.
+var topicDeletionPolicyOrder = []crd.TopicDeletionPolicy{
+	TopicDeletionPolicyRetain,
+	TopicDeletionPolicyGracePeriod,
+	TopicDeletionPolicyDelete,
+}
+
+// getTopicOwnerLabel returns the label naming an app which declares a KafkaTopic. The names of
+// labels are limited to 63 characters, so longer app names are truncated and suffixed with a
+// hash of the name to keep them unique.
+func getTopicOwnerLabel(appName string) string {
+	name := appName
+	if len(name) > 63 {
+		hash := fmt.Sprintf("%x", sha256.Sum256([]byte(appName)))
+		name = fmt.Sprintf("%s-%s", name[:54], hash[:8])
+	}
+	return topicOwnerLabelPrefix + name
+}
+
+// getTopicOwners returns the apps declaring each topic, keyed by the name of the topic on the
+// Kafka server. Apps which are being deleted do not own their topics anymore.
+func getTopicOwners(env *crd.ClowdEnvironment, appList *crd.ClowdAppList) map[string][]string {
+	owners := map[string][]string{}
+
+	for _, app := range appList.Items {
+		if app.GetDeletionTimestamp() != nil {
+			continue
+		}
+		for _, topic := range getAppTopics(&app) {
+			topicName := getTopicName(topic, *env, app.Namespace)
+			if !utils.Contains(owners[topicName], app.Name) {
+				owners[topicName] = append(owners[topicName], app.Name)
+			}
+		}
+	}
+
+	for _, names := range owners {
+		sort.Strings(names)
+	}
+
+	return owners
+}
+
+// getDeclaredTopicDeletionPolicy returns the deletion policy the apps declaring a topic set for
+// it, the one keeping the topic the longest when they differ, or an empty policy when none do.
+func getDeclaredTopicDeletionPolicy(env *crd.ClowdEnvironment, appList *crd.ClowdAppList, topicName string) crd.TopicDeletionPolicy {
+	declared := map[crd.TopicDeletionPolicy]bool{}
+
+	for _, app := range appList.Items {
+		if app.GetDeletionTimestamp() != nil {
+			continue
+		}
+		for _, topic := range getAppTopics(&app) {
+			if topic.DeletionPolicy != "" && getTopicName(topic, *env, app.Namespace) == topicName {
+				declared[topic.DeletionPolicy] = true
+			}
+		}
+	}
+
+	for _, policy := range topicDeletionPolicyOrder {
+		if declared[policy] {
+			return policy
+		}
+	}
+	return ""
+}
+
+// setTopicOwners labels a KafkaTopic with the apps declaring it and records the deletion policy
+// they set, returning whether anything changed. An orphaned topic keeps the policy it was given.
+func setTopicOwners(k *strimzi.KafkaTopic, owners []string, policy crd.TopicDeletionPolicy) bool {
+	changed := false
+
+	ownerLabels := map[string]bool{}
+	for _, owner := range owners {
+		ownerLabels[getTopicOwnerLabel(owner)] = true
+	}
+
+	labels := k.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	for key := range labels {
+		if strings.HasPrefix(key, topicOwnerLabelPrefix) && !ownerLabels[key] {
+			delete(labels, key)
+			changed = true
+		}
+	}
+	for key := range ownerLabels {
+		if _, ok := labels[key]; !ok {
+			labels[key] = "true"
+			changed = true
+		}
+	}
+	k.SetLabels(labels)
+
+	annotations := k.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	// The policy of a topic no app declares anymore is the one its apps last set
+	if len(owners) > 0 && annotations[topicDeletionPolicyAnnotation] != string(policy) {
+		changed = true
+		if policy == "" {
+			delete(annotations, topicDeletionPolicyAnnotation)
+		} else {
+			annotations[topicDeletionPolicyAnnotation] = string(policy)
+		}
+	}
+	if _, ok := annotations[topicOrphanedAtAnnotation]; ok && len(owners) > 0 {
+		delete(annotations, topicOrphanedAtAnnotation)
+		changed = true
+	}
+	k.SetAnnotations(annotations)
+
+	return changed
+}
+
+// getTopicDeletionPolicy returns the deletion policy of a KafkaTopic, the one recorded from its
+// apps if any, otherwise the one of the environment.
+func getTopicDeletionPolicy(env *crd.ClowdEnvironment, k *strimzi.KafkaTopic) crd.TopicDeletionPolicy {
+	if policy := crd.TopicDeletionPolicy(k.GetAnnotations()[topicDeletionPolicyAnnotation]); policy != "" {
+		return policy
+	}
+	if policy := env.Spec.Providers.Kafka.TopicDeletionPolicy; policy != "" {
+		return policy
+	}
+	return TopicDeletionPolicyRetain
+}
+
+func getTopicDeletionGracePeriod(env *crd.ClowdEnvironment) time.Duration {
+	if env.Spec.Providers.Kafka.TopicDeletionGracePeriod != nil {
+		return env.Spec.Providers.Kafka.TopicDeletionGracePeriod.Duration
+	}
+	return DefaultTopicDeletionGracePeriod
+}
+
+// cleanupTopics brings the owners of the KafkaTopics of the environment up to date, and applies
+// the deletion policy of the topics no app declares anymore.
+func (s *strimziProvider) cleanupTopics() error {
+	appList, err := s.getAppsInCluster()
+	if err != nil {
+		return errors.Wrap("Topic cleanup failed: Error listing apps", err)
+	}
+
+	topics := strimzi.KafkaTopicList{}
+	opts := []client.ListOption{
+		client.InNamespace(getKafkaNamespace(s.Env)),
+		client.MatchingLabels{"strimzi.io/cluster": getKafkaName(s.Env), "env": s.Env.Name},
+	}
+	if err := s.Client.List(s.Ctx, &topics, opts...); err != nil {
+		return errors.Wrap("Topic cleanup failed: Error listing topics", err)
+	}
+
+	owners := getTopicOwners(s.Env, appList)
+	now := time.Now().UTC()
+
+	for i := range topics.Items {
+		k := &topics.Items[i]
+		if !isOwnedByEnv(k, s.Env) {
+			continue
+		}
+
+		topicOwners := owners[k.Name]
+		changed := setTopicOwners(k, topicOwners, getDeclaredTopicDeletionPolicy(s.Env, appList, k.Name))
+
+		if len(topicOwners) == 0 {
+			var remove bool
+			var remaining time.Duration
+			remove, remaining, changed = applyTopicDeletionPolicy(s.Env, k, now, changed)
+			if remaining > 0 {
+				// Come back to the topic once its grace period is over
+				s.Requeue(remaining)
+			}
+			if remove {
+				s.Log.Info("Deleting orphaned topic", "namespace", k.Namespace, "name", k.Name)
+				if err := s.Client.Delete(s.Ctx, k); err != nil && !k8serr.IsNotFound(err) {
+					return errors.Wrap(fmt.Sprintf("could not delete topic [%s]", k.Name), err)
+				}
+				continue
+			}
+		}
+
+		if changed {
+			if err := s.Client.Update(s.Ctx, k); err != nil {
+				return errors.Wrap(fmt.Sprintf("could not update topic [%s]", k.Name), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// applyTopicDeletionPolicy returns whether a KafkaTopic no app declares is to be deleted, how
+// long until it is when it has a grace period left, and whether it has otherwise changed,
+// marking the time it was orphaned at when it has a grace period.
+func applyTopicDeletionPolicy(env *crd.ClowdEnvironment, k *strimzi.KafkaTopic, now time.Time, changed bool) (bool, time.Duration, bool) {
+	switch getTopicDeletionPolicy(env, k) {
+	case TopicDeletionPolicyDelete:
+		return true, 0, changed
+	case TopicDeletionPolicyGracePeriod:
+		gracePeriod := getTopicDeletionGracePeriod(env)
+		annotations := k.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		orphanedAt, err := time.Parse(time.RFC3339, annotations[topicOrphanedAtAnnotation])
+		if err != nil {
+			annotations[topicOrphanedAtAnnotation] = now.Format(time.RFC3339)
+			k.SetAnnotations(annotations)
+			return false, gracePeriod, true
+		}
+		if remaining := gracePeriod - now.Sub(orphanedAt); remaining > 0 {
+			return false, remaining, changed
+		}
+		return true, 0, changed
+	default:
+		return false, 0, changed
+	}
+}
+
+func isOwnedByEnv(k *strimzi.KafkaTopic, env *crd.ClowdEnvironment) bool {
+	for _, owner := range k.GetOwnerReferences() {
+		if owner.UID == env.GetUID() {
+			return true
+		}
+	}
+	return false
+}
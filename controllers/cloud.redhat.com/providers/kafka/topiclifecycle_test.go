@@ -0,0 +1,113 @@
+package kafka
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	crd "github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1alpha1"
+	strimzi "github.com/RedHatInsights/strimzi-client-go/apis/kafka.strimzi.io/v1beta2"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+func TestTopicOwners(t *testing.T) {
+	env := &crd.ClowdEnvironment{ObjectMeta: metav1.ObjectMeta{Name: "env"}}
+	now := metav1.Now()
+
+	deleting := makeRoleTestApp("deleting", crd.KafkaTopicSpec{TopicName: "events"})
+	deleting.DeletionTimestamp = &now
+
+	appList := &crd.ClowdAppList{Items: []crd.ClowdApp{
+		makeRoleTestApp("producer", crd.KafkaTopicSpec{TopicName: "events", DeletionPolicy: TopicDeletionPolicyDelete}),
+		makeRoleTestApp("consumer",
+			crd.KafkaTopicSpec{TopicName: "events", DeletionPolicy: TopicDeletionPolicyGracePeriod},
+			crd.KafkaTopicSpec{TopicName: "audit"},
+		),
+		deleting,
+	}}
+
+	owners := getTopicOwners(env, appList)
+	assert.Equal(t, map[string][]string{
+		"events": {"consumer", "producer"},
+		"audit":  {"consumer"},
+	}, owners)
+
+	assert.Equal(t, TopicDeletionPolicyGracePeriod, getDeclaredTopicDeletionPolicy(env, appList, "events"))
+	assert.Equal(t, crd.TopicDeletionPolicy(""), getDeclaredTopicDeletionPolicy(env, appList, "audit"))
+
+	k := &strimzi.KafkaTopic{ObjectMeta: metav1.ObjectMeta{
+		Name:   "events",
+		Labels: map[string]string{"env": "env", getTopicOwnerLabel("deleting"): "true"},
+	}}
+	assert.True(t, setTopicOwners(k, owners["events"], TopicDeletionPolicyGracePeriod))
+	assert.Equal(t, map[string]string{
+		"env":                          "env",
+		getTopicOwnerLabel("consumer"): "true",
+		getTopicOwnerLabel("producer"): "true",
+	}, k.Labels)
+	assert.Equal(t, string(TopicDeletionPolicyGracePeriod), k.Annotations[topicDeletionPolicyAnnotation])
+	assert.False(t, setTopicOwners(k, owners["events"], TopicDeletionPolicyGracePeriod))
+
+	// An orphaned topic keeps the policy its apps set
+	assert.True(t, setTopicOwners(k, nil, ""))
+	assert.Equal(t, map[string]string{"env": "env"}, k.Labels)
+	assert.Equal(t, TopicDeletionPolicyGracePeriod, getTopicDeletionPolicy(env, k))
+}
+
+func TestTopicDeletionPolicy(t *testing.T) {
+	env := &crd.ClowdEnvironment{}
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	k := &strimzi.KafkaTopic{}
+	remove, remaining, changed := applyTopicDeletionPolicy(env, k, now, false)
+	assert.False(t, remove)
+	assert.Zero(t, remaining)
+	assert.False(t, changed)
+
+	env.Spec.Providers.Kafka.TopicDeletionPolicy = TopicDeletionPolicyDelete
+	remove, _, _ = applyTopicDeletionPolicy(env, k, now, false)
+	assert.True(t, remove)
+
+	env.Spec.Providers.Kafka.TopicDeletionPolicy = TopicDeletionPolicyGracePeriod
+	env.Spec.Providers.Kafka.TopicDeletionGracePeriod = &metav1.Duration{Duration: time.Hour}
+	remove, remaining, changed = applyTopicDeletionPolicy(env, k, now, false)
+	assert.False(t, remove)
+	assert.Equal(t, time.Hour, remaining)
+	assert.True(t, changed)
+	assert.Equal(t, "2023-06-01T12:00:00Z", k.Annotations[topicOrphanedAtAnnotation])
+
+	remove, remaining, changed = applyTopicDeletionPolicy(env, k, now.Add(20*time.Minute), false)
+	assert.False(t, remove)
+	assert.Equal(t, 40*time.Minute, remaining)
+	assert.False(t, changed)
+
+	remove, remaining, _ = applyTopicDeletionPolicy(env, k, now.Add(time.Hour), false)
+	assert.True(t, remove)
+	assert.Zero(t, remaining)
+
+	// The policy recorded from the apps of the topic wins over the one of the environment
+	k.Annotations[topicDeletionPolicyAnnotation] = string(TopicDeletionPolicyRetain)
+	remove, remaining, _ = applyTopicDeletionPolicy(env, k, now.Add(time.Hour), false)
+	assert.False(t, remove)
+	assert.Zero(t, remaining)
+}
+
+func TestTopicOwnerLabel(t *testing.T) {
+	assert.Equal(t, "owner.kafka.cloud.redhat.com/puptoo", getTopicOwnerLabel("puptoo"))
+
+	longName := strings.Repeat("a", 253)
+	label := getTopicOwnerLabel(longName)
+	name := strings.TrimPrefix(label, topicOwnerLabelPrefix)
+	assert.Len(t, name, 63)
+	assert.Empty(t, validation.IsQualifiedName(label))
+	assert.Equal(t, label, getTopicOwnerLabel(longName))
+	assert.NotEqual(t, label, getTopicOwnerLabel(longName+"b"))
+
+	k := &strimzi.KafkaTopic{}
+	assert.True(t, setTopicOwners(k, []string{longName}, ""))
+	assert.False(t, setTopicOwners(k, []string{longName}, ""))
+	assert.True(t, setTopicOwners(k, nil, ""))
+	assert.Empty(t, k.Labels)
+}
@@ -0,0 +1,106 @@
+package kafka
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	crd "github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1alpha1"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/errors"
+	strimzi "github.com/RedHatInsights/strimzi-client-go/apis/kafka.strimzi.io/v1beta2"
+	"k8s.io/client-go/tools/record"
+)
+
+// getTopicMismatches returns how an existing KafkaTopic differs from the spec an app requests for
+// it. A topic with more partitions or replicas than requested matches, as does one with config
+// keys the app does not request.
+func getTopicMismatches(topic crd.KafkaTopicSpec, k *strimzi.KafkaTopic) ([]crd.KafkaTopicMismatch, error) {
+	mismatches := []crd.KafkaTopicMismatch{}
+
+	checkCount := func(field string, requested int32, actual *int32) {
+		if requested == 0 {
+			return
+		}
+		if actual == nil || *actual < requested {
+			mismatch := crd.KafkaTopicMismatch{
+				TopicName: topic.TopicName,
+				Field:     field,
+				Requested: strconv.Itoa(int(requested)),
+			}
+			if actual != nil {
+				mismatch.Actual = strconv.Itoa(int(*actual))
+			}
+			mismatches = append(mismatches, mismatch)
+		}
+	}
+	checkCount("partitions", topic.Partitions, k.Spec.Partitions)
+	checkCount("replicas", topic.Replicas, k.Spec.Replicas)
+
+	actualConfig := map[string]interface{}{}
+	if k.Spec.Config != nil && len(k.Spec.Config.Raw) > 0 {
+		// Numbers are kept as written so that they compare with the requested strings
+		decoder := json.NewDecoder(bytes.NewReader(k.Spec.Config.Raw))
+		decoder.UseNumber()
+		if err := decoder.Decode(&actualConfig); err != nil {
+			return nil, errors.Wrap(fmt.Sprintf("could not read config of topic [%s]", k.Name), err)
+		}
+	}
+
+	keys := []string{}
+	for key := range topic.Config {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		actual, ok := actualConfig[key]
+		if ok && fmt.Sprint(actual) == topic.Config[key] {
+			continue
+		}
+		mismatch := crd.KafkaTopicMismatch{
+			TopicName: topic.TopicName,
+			Field:     fmt.Sprintf("config.%s", key),
+			Requested: topic.Config[key],
+		}
+		if ok {
+			mismatch.Actual = fmt.Sprint(actual)
+		}
+		mismatches = append(mismatches, mismatch)
+	}
+
+	return mismatches, nil
+}
+
+func describeTopicMismatch(mismatch crd.KafkaTopicMismatch) string {
+	actual := mismatch.Actual
+	if actual == "" {
+		actual = "unset"
+	}
+	return fmt.Sprintf("topic [%s] %s is %s, requested %s", mismatch.TopicName, mismatch.Field, actual, mismatch.Requested)
+}
+
+// DescribeTopicMismatches returns a message listing the differences between the existing
+// KafkaTopics and the spec an app requests for them.
+func DescribeTopicMismatches(mismatches []crd.KafkaTopicMismatch) string {
+	descriptions := []string{}
+	for _, mismatch := range mismatches {
+		descriptions = append(descriptions, describeTopicMismatch(mismatch))
+	}
+	return strings.Join(descriptions, "; ")
+}
+
+// recordTopicMismatches raises a warning event on the app for each difference between the existing
+// KafkaTopics and the spec it requests for them.
+func recordTopicMismatches(ctx context.Context, app *crd.ClowdApp, mismatches []crd.KafkaTopicMismatch) {
+	recorder, ok := ctx.Value(errors.ClowdKey("recorder")).(*record.EventRecorder)
+	if !ok || recorder == nil || *recorder == nil {
+		return
+	}
+	for _, mismatch := range mismatches {
+		(*recorder).Event(app, "Warning", "KafkaTopicMismatch", describeTopicMismatch(mismatch))
+	}
+}
@@ -0,0 +1,72 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	crd "github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1alpha1"
+	"github.com/RedHatInsights/rhc-osdk-utils/utils"
+	strimzi "github.com/RedHatInsights/strimzi-client-go/apis/kafka.strimzi.io/v1beta2"
+	"github.com/stretchr/testify/assert"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func TestTopicMismatches(t *testing.T) {
+	k := &strimzi.KafkaTopic{
+		Spec: &strimzi.KafkaTopicSpec{
+			Partitions: utils.Int32Ptr(6),
+			Replicas:   utils.Int32Ptr(3),
+			Config: &apiextensions.JSON{
+				Raw: []byte(`{"retention.ms": 86400000, "cleanup.policy": "delete", "segment.bytes": "1048576"}`),
+			},
+		},
+	}
+
+	mismatches, err := getTopicMismatches(crd.KafkaTopicSpec{
+		TopicName:  "events",
+		Partitions: 3,
+		Replicas:   3,
+		Config:     map[string]string{"retention.ms": "86400000", "segment.bytes": "1048576"},
+	}, k)
+	assert.NoError(t, err)
+	assert.Empty(t, mismatches)
+
+	mismatches, err = getTopicMismatches(crd.KafkaTopicSpec{
+		TopicName:  "events",
+		Partitions: 12,
+		Config: map[string]string{
+			"cleanup.policy":      "compact",
+			"retention.ms":        "3600000",
+			"min.insync.replicas": "2",
+		},
+	}, k)
+	assert.NoError(t, err)
+	assert.Equal(t, []crd.KafkaTopicMismatch{
+		{TopicName: "events", Field: "partitions", Requested: "12", Actual: "6"},
+		{TopicName: "events", Field: "config.cleanup.policy", Requested: "compact", Actual: "delete"},
+		{TopicName: "events", Field: "config.min.insync.replicas", Requested: "2"},
+		{TopicName: "events", Field: "config.retention.ms", Requested: "3600000", Actual: "86400000"},
+	}, mismatches)
+
+	assert.Equal(t,
+		"topic [events] partitions is 6, requested 12; topic [events] config.min.insync.replicas is unset, requested 2",
+		DescribeTopicMismatches([]crd.KafkaTopicMismatch{mismatches[0], mismatches[2]}),
+	)
+}
+
+func TestReportTopicMismatches(t *testing.T) {
+	a := &appInterface{}
+	a.Ctx = context.Background()
+	a.Env = &crd.ClowdEnvironment{}
+	app := &crd.ClowdApp{}
+	mismatches := []crd.KafkaTopicMismatch{{TopicName: "events", Field: "partitions", Requested: "12", Actual: "6"}}
+
+	assert.NoError(t, a.reportTopicMismatches(app, mismatches))
+	assert.Equal(t, mismatches, app.Status.KafkaTopicMismatches)
+
+	a.Env.Spec.Providers.Kafka.StrictTopicValidation = true
+	assert.Error(t, a.reportTopicMismatches(app, mismatches))
+
+	assert.NoError(t, a.reportTopicMismatches(app, nil))
+	assert.Nil(t, app.Status.KafkaTopicMismatches)
+}
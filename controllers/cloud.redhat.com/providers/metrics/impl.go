@@ -9,7 +9,6 @@ import (
 	webProvider "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/web"
 
 	prom "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
-	apps "k8s.io/api/apps/v1"
 	core "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -27,9 +26,8 @@ func makeMetrics(cache *rc.ObjectCache, deployment *crd.Deployment, app *crd.Clo
 		return err
 	}
 
-	d := &apps.Deployment{}
-
-	if err := cache.Get(deployProvider.CoreDeployment, d, app.GetDeploymentNamespacedName(deployment)); err != nil {
+	w, err := deployProvider.GetWorkload(cache, app, deployment)
+	if err != nil {
 		return err
 	}
 
@@ -44,7 +42,7 @@ func makeMetrics(cache *rc.ObjectCache, deployment *crd.Deployment, app *crd.Clo
 
 	s.Spec.Ports = append(s.Spec.Ports, metricsPort)
 
-	d.Spec.Template.Spec.Containers[0].Ports = append(d.Spec.Template.Spec.Containers[0].Ports,
+	w.Template.Spec.Containers[0].Ports = append(w.Template.Spec.Containers[0].Ports,
 		core.ContainerPort{
 			Name:          "metrics",
 			ContainerPort: port,
@@ -56,7 +54,7 @@ func makeMetrics(cache *rc.ObjectCache, deployment *crd.Deployment, app *crd.Clo
 		return err
 	}
 
-	return cache.Update(deployProvider.CoreDeployment, d)
+	return w.Update(cache)
 }
 
 func createMetricsOnDeployments(cache *rc.ObjectCache, env *crd.ClowdEnvironment, app *crd.ClowdApp, c *config.AppConfig) error {
@@ -0,0 +1,195 @@
+package migrations
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	crd "github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1alpha1"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/errors"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers"
+	deployProvider "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/deployment"
+	jobProvider "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/job"
+	provutils "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/utils"
+
+	rc "github.com/RedHatInsights/rhc-osdk-utils/resourceCache"
+	"github.com/RedHatInsights/rhc-osdk-utils/utils"
+
+	batch "k8s.io/api/batch/v1"
+	core "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// MigrationJob is the job running the migrations of a ClowdApp for an image.
+var MigrationJob = rc.NewMultiResourceIdent(ProvName, "migration_job", &batch.Job{})
+
+const (
+	// PhaseRunning means the migrations for the current image have not finished yet.
+	PhaseRunning = "Running"
+	// PhaseSucceeded means the migrations for the current image have been applied.
+	PhaseSucceeded = "Succeeded"
+	// PhaseFailed means the migrations for the current image have failed.
+	PhaseFailed = "Failed"
+)
+
+type migrationsProvider struct {
+	providers.Provider
+}
+
+// NewMigrationsProvider returns a new migrations provider.
+func NewMigrationsProvider(p *providers.Provider) (providers.ClowderProvider, error) {
+	p.Cache.AddPossibleGVKFromIdent(MigrationJob)
+	return &migrationsProvider{Provider: *p}, nil
+}
+
+func (mp *migrationsProvider) EnvProvide() error {
+	return nil
+}
+
+// GetMigrationJobName returns the name of the job running the migrations for the given image,
+// so that a new job is run for every change of the image.
+func GetMigrationJobName(app *crd.ClowdApp, job *crd.Job) string {
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(job.PodSpec.Image)))
+	return fmt.Sprintf("%s-%s-%s", app.Name, job.Name, hash[:8])
+}
+
+func (mp *migrationsProvider) Provide(app *crd.ClowdApp) error {
+	if app.Spec.Migrations == nil {
+		app.Status.Migrations = nil
+		return nil
+	}
+
+	var job *crd.Job
+	for i := range app.Spec.Jobs {
+		if app.Spec.Jobs[i].Name == app.Spec.Migrations.JobName {
+			job = &app.Spec.Jobs[i]
+		}
+	}
+	if job == nil {
+		return errors.NewClowderError(fmt.Sprintf("migrations job [%s] not found in jobs", app.Spec.Migrations.JobName))
+	}
+
+	status := app.Status.Migrations
+	if status == nil {
+		status = &crd.MigrationStatus{}
+		app.Status.Migrations = status
+	}
+
+	nn := types.NamespacedName{
+		Name:      GetMigrationJobName(app, job),
+		Namespace: app.Namespace,
+	}
+
+	status.JobName = nn.Name
+	status.Image = job.PodSpec.Image
+
+	if status.AppliedImage == job.PodSpec.Image {
+		status.Phase = PhaseSucceeded
+		status.Message = ""
+		return mp.keepJob(nn)
+	}
+
+	j := &batch.Job{}
+	if err := mp.Cache.Create(MigrationJob, nn, j); err != nil {
+		return err
+	}
+
+	// The pod template of a job is immutable and a change of the image gives a new job name,
+	// so an existing job is left as it is.
+	if j.GetUID() == "" {
+		if err := mp.makeJob(app, job, nn, j); err != nil {
+			return err
+		}
+	}
+
+	if err := mp.Cache.Update(MigrationJob, j); err != nil {
+		return err
+	}
+
+	status.Phase, status.Message = getJobPhase(j)
+
+	if status.Phase == PhaseSucceeded {
+		status.AppliedImage = job.PodSpec.Image
+		status.AppliedTime = j.Status.CompletionTime
+		return nil
+	}
+
+	return mp.holdWorkloads()
+}
+
+// keepJob keeps the job of the already applied migrations around, if it still exists, so
+// that its logs can be read. It is not recreated if it has been deleted.
+func (mp *migrationsProvider) keepJob(nn types.NamespacedName) error {
+	if err := mp.Client.Get(mp.Ctx, nn, &batch.Job{}); err != nil {
+		if k8serr.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	j := &batch.Job{}
+	if err := mp.Cache.Create(MigrationJob, nn, j); err != nil {
+		return err
+	}
+	return mp.Cache.Update(MigrationJob, j)
+}
+
+func (mp *migrationsProvider) makeJob(app *crd.ClowdApp, job *crd.Job, nn types.NamespacedName, j *batch.Job) error {
+	labels := app.GetLabels()
+	labels["job"] = job.Name
+	app.SetObjectMeta(j, crd.Name(nn.Name), crd.Labels(labels))
+
+	j.Spec.Template.ObjectMeta.Labels = labels
+
+	if err := jobProvider.ApplyJobSpec(mp.Env, app, nn, job, j); err != nil {
+		return err
+	}
+
+	utils.UpdateAnnotations(&j.Spec.Template, provutils.KubeLinterAnnotations)
+	utils.UpdateAnnotations(j, provutils.KubeLinterAnnotations, app.ObjectMeta.Annotations)
+
+	return nil
+}
+
+func getJobPhase(j *batch.Job) (string, string) {
+	if j.Status.Succeeded > 0 {
+		return PhaseSucceeded, ""
+	}
+
+	for _, condition := range j.Status.Conditions {
+		if condition.Type == batch.JobFailed && condition.Status == core.ConditionTrue {
+			return PhaseFailed, condition.Message
+		}
+	}
+
+	return PhaseRunning, ""
+}
+
+// holdWorkloads keeps the pod templates of the workloads as they are in the cluster until the
+// migrations have been applied. Workloads which do not exist yet are created without any
+// replicas.
+func (mp *migrationsProvider) holdWorkloads() error {
+	workloads, err := deployProvider.ListWorkloads(mp.Cache)
+	if err != nil {
+		return err
+	}
+
+	for _, workload := range workloads {
+		template, err := workload.GetClusterTemplate(mp.Ctx, mp.Client)
+		if err != nil {
+			return err
+		}
+
+		if template != nil {
+			*workload.Template = *template
+		} else {
+			workload.SetReplicas(0)
+		}
+
+		if err := workload.Update(mp.Cache); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
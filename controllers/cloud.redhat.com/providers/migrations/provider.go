@@ -0,0 +1,19 @@
+package migrations
+
+import (
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers"
+)
+
+// ProvName sets the provider name identifier
+var ProvName = "migrations"
+
+// GetMigrations returns the correct migrations provider.
+func GetMigrations(c *providers.Provider) (providers.ClowderProvider, error) {
+	return NewMigrationsProvider(c)
+}
+
+func init() {
+	// Run after every provider that modifies the pod templates, so that a pending migration
+	// can hold back the whole of the new template
+	providers.ProvidersRegistration.Register(GetMigrations, 100, ProvName)
+}
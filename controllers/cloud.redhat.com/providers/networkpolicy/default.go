@@ -0,0 +1,173 @@
+package networkpolicy
+
+import (
+	crd "github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1alpha1"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers"
+	deployProvider "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/deployment"
+	provutils "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/utils"
+
+	rc "github.com/RedHatInsights/rhc-osdk-utils/resourceCache"
+	"github.com/RedHatInsights/rhc-osdk-utils/utils"
+
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// AppNetworkPolicy is the NetworkPolicy restricting ingress to a ClowdApp deployment.
+var AppNetworkPolicy = rc.NewMultiResourceIdent(ProvName, "app_network_policy", &networking.NetworkPolicy{})
+
+type networkPolicyProvider struct {
+	providers.Provider
+}
+
+// NewNetworkPolicyProvider returns a new network policy provider.
+func NewNetworkPolicyProvider(p *providers.Provider) (providers.ClowderProvider, error) {
+	p.Cache.AddPossibleGVKFromIdent(AppNetworkPolicy)
+	return &networkPolicyProvider{Provider: *p}, nil
+}
+
+func (np *networkPolicyProvider) EnvProvide() error {
+	return nil
+}
+
+func (np *networkPolicyProvider) Provide(app *crd.ClowdApp) error {
+	if !np.Env.Spec.Providers.NetworkPolicy.Enabled {
+		return nil
+	}
+
+	peers, err := np.makeIngressPeers(app)
+	if err != nil {
+		return err
+	}
+
+	workloads, err := deployProvider.ListWorkloads(np.Cache)
+	if err != nil {
+		return err
+	}
+
+	for _, workload := range workloads {
+		nn := types.NamespacedName{
+			Name:      workload.Object.GetName(),
+			Namespace: workload.Object.GetNamespace(),
+		}
+
+		policy := &networking.NetworkPolicy{}
+		if err := np.Cache.Create(AppNetworkPolicy, nn, policy); err != nil {
+			return err
+		}
+
+		policy.Spec.PodSelector = metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"pod": nn.Name,
+			},
+		}
+		policy.Spec.Ingress = []networking.NetworkPolicyIngressRule{{
+			From: peers,
+		}}
+		policy.Spec.PolicyTypes = []networking.PolicyType{"Ingress"}
+
+		labeler := utils.GetCustomLabeler(nil, nn, app)
+		labeler(policy)
+
+		if err := np.Cache.Update(AppNetworkPolicy, policy); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func namespacePeer(namespace string) networking.NetworkPolicyPeer {
+	return networking.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"kubernetes.io/metadata.name": namespace,
+			},
+		},
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// makeIngressPeers returns the sources which are allowed to reach the deployments of the
+// ClowdApp.
+func (np *networkPolicyProvider) makeIngressPeers(app *crd.ClowdApp) ([]networking.NetworkPolicyPeer, error) {
+	peers := []networking.NetworkPolicyPeer{}
+
+	// The deployments of the same ClowdApp can always talk to each other
+	peers = append(peers, networking.NetworkPolicyPeer{
+		PodSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"app": app.GetLabels()["app"],
+			},
+		},
+	})
+
+	appList, err := np.Env.GetAppsInEnv(np.Ctx, np.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dependent := range appList.Items {
+		if dependent.Name == app.Name && dependent.Namespace == app.Namespace {
+			continue
+		}
+		if !contains(dependent.Spec.Dependencies, app.Name) && !contains(dependent.Spec.OptionalDependencies, app.Name) {
+			continue
+		}
+		peer := namespacePeer(dependent.Namespace)
+		peer.PodSelector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"app": dependent.GetLabels()["app"],
+			},
+		}
+		peers = append(peers, peer)
+	}
+
+	// Resources created by the ClowdEnvironment, such as the web gateway, are labelled with the
+	// name of the environment
+	envPeer := namespacePeer(np.Env.Status.TargetNamespace)
+	envPeer.PodSelector = &metav1.LabelSelector{
+		MatchLabels: np.Env.GetLabels(),
+	}
+	peers = append(peers, envPeer)
+
+	if clowderNs, err := provutils.GetClowderNamespace(); err == nil {
+		peers = append(peers, namespacePeer(clowderNs))
+	}
+
+	// Prometheus instances are allowed to scrape from any namespace
+	peers = append(peers, networking.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{},
+		PodSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"app.kubernetes.io/name": "prometheus",
+			},
+		},
+	})
+
+	// The ingress controller and cluster monitoring namespaces on OpenShift
+	for _, group := range []string{"ingress", "monitoring"} {
+		peers = append(peers, networking.NetworkPolicyPeer{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"network.openshift.io/policy-group": group,
+				},
+			},
+		})
+	}
+
+	for _, namespace := range np.Env.Spec.Providers.NetworkPolicy.AllowedNamespaces {
+		peers = append(peers, namespacePeer(namespace))
+	}
+
+	return peers, nil
+}
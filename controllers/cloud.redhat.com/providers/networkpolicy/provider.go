@@ -0,0 +1,17 @@
+package networkpolicy
+
+import (
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers"
+)
+
+// ProvName sets the provider name identifier
+var ProvName = "networkpolicy"
+
+// GetNetworkPolicy returns the correct network policy provider.
+func GetNetworkPolicy(c *providers.Provider) (providers.ClowderProvider, error) {
+	return NewNetworkPolicyProvider(c)
+}
+
+func init() {
+	providers.ProvidersRegistration.Register(GetNetworkPolicy, 98, ProvName)
+}
@@ -0,0 +1,133 @@
+package poddisruptionbudget
+
+import (
+	crd "github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1alpha1"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/autoscaler"
+
+	rc "github.com/RedHatInsights/rhc-osdk-utils/resourceCache"
+	"github.com/RedHatInsights/rhc-osdk-utils/utils"
+
+	keda "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	v2 "k8s.io/api/autoscaling/v2"
+	policy "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// CorePodDisruptionBudget is the PodDisruptionBudget protecting a ClowdApp deployment.
+var CorePodDisruptionBudget = rc.NewMultiResourceIdent(ProvName, "core_pdb", &policy.PodDisruptionBudget{})
+
+type podDisruptionBudgetProvider struct {
+	providers.Provider
+}
+
+// NewPodDisruptionBudgetProvider returns a new pod disruption budget provider.
+func NewPodDisruptionBudgetProvider(p *providers.Provider) (providers.ClowderProvider, error) {
+	p.Cache.AddPossibleGVKFromIdent(CorePodDisruptionBudget)
+	return &podDisruptionBudgetProvider{Provider: *p}, nil
+}
+
+func (pp *podDisruptionBudgetProvider) EnvProvide() error {
+	return nil
+}
+
+func (pp *podDisruptionBudgetProvider) Provide(app *crd.ClowdApp) error {
+	for _, deployment := range app.Spec.Deployments {
+		innerDeployment := deployment
+		if err := pp.makePodDisruptionBudget(app, &innerDeployment); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (pp *podDisruptionBudgetProvider) makePodDisruptionBudget(app *crd.ClowdApp, deployment *crd.Deployment) error {
+	config := pp.Env.Spec.Providers.PodDisruptionBudget
+	override := deployment.PodDisruptionBudget
+
+	enabled := config.Enabled
+	if override != nil && override.Enabled != nil {
+		enabled = *override.Enabled
+	}
+	if !enabled {
+		return nil
+	}
+
+	maxReplicas, err := pp.getMaxReplicas(app, deployment)
+	if err != nil {
+		return err
+	}
+
+	// A budget on a single replica would either block node drains entirely or not protect
+	// anything, so none is created and any existing one is removed
+	if maxReplicas <= 1 {
+		return nil
+	}
+
+	nn := app.GetDeploymentNamespacedName(deployment)
+
+	pdb := &policy.PodDisruptionBudget{}
+	if err := pp.Cache.Create(CorePodDisruptionBudget, nn, pdb); err != nil {
+		return err
+	}
+
+	pdb.Spec.Selector = &metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			"pod": nn.Name,
+		},
+	}
+
+	maxUnavailable := intstr.FromInt(1)
+	if config.MaxUnavailable != nil {
+		maxUnavailable = *config.MaxUnavailable
+	}
+
+	pdb.Spec.MaxUnavailable = &maxUnavailable
+	pdb.Spec.MinAvailable = nil
+	if override != nil {
+		if override.MaxUnavailable != nil {
+			pdb.Spec.MaxUnavailable = override.MaxUnavailable
+		} else if override.MinAvailable != nil {
+			pdb.Spec.MaxUnavailable = nil
+			pdb.Spec.MinAvailable = override.MinAvailable
+		}
+	}
+
+	labeler := utils.GetCustomLabeler(nil, nn, app)
+	labeler(pdb)
+
+	return pp.Cache.Update(CorePodDisruptionBudget, pdb)
+}
+
+// getMaxReplicas returns the highest number of replicas the deployment can be running. If the
+// deployment is scaled by an autoscaler, the maximum is read from the autoscaler resource
+// created by the autoscaler provider.
+func (pp *podDisruptionBudgetProvider) getMaxReplicas(app *crd.ClowdApp, deployment *crd.Deployment) (int32, error) {
+	mode := pp.Env.Spec.Providers.AutoScaler.Mode
+	if mode != autoscaler.ENABLED && mode != autoscaler.KEDA {
+		return *deployment.GetReplicaCount(), nil
+	}
+
+	nn := app.GetDeploymentNamespacedName(deployment)
+
+	if deployment.AutoScalerSimple != nil {
+		hpa := &v2.HorizontalPodAutoscaler{}
+		if err := pp.Cache.Get(autoscaler.SimpleAutoScaler, hpa, nn); err != nil {
+			return 0, err
+		}
+		return hpa.Spec.MaxReplicas, nil
+	}
+
+	if deployment.AutoScaler != nil {
+		scaledObject := &keda.ScaledObject{}
+		if err := pp.Cache.Get(autoscaler.CoreAutoScaler, scaledObject, nn); err != nil {
+			return 0, err
+		}
+		if scaledObject.Spec.MaxReplicaCount != nil {
+			return *scaledObject.Spec.MaxReplicaCount, nil
+		}
+	}
+
+	return *deployment.GetReplicaCount(), nil
+}
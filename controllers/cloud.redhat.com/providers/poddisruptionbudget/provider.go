@@ -0,0 +1,18 @@
+package poddisruptionbudget
+
+import (
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers"
+)
+
+// ProvName sets the provider name identifier
+var ProvName = "poddisruptionbudget"
+
+// GetPodDisruptionBudget returns the correct pod disruption budget provider.
+func GetPodDisruptionBudget(c *providers.Provider) (providers.ClowderProvider, error) {
+	return NewPodDisruptionBudgetProvider(c)
+}
+
+func init() {
+	// Run after the autoscaler provider so that autoscaled replica counts are known
+	providers.ProvidersRegistration.Register(GetPodDisruptionBudget, 11, ProvName)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	crd "github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1alpha1"
 	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/config"
@@ -32,6 +33,7 @@ type providerAccessor struct {
 	SetupProvider    func(c *Provider) (ClowderProvider, error)
 	Order            int
 	Name             string
+	FinalizesApps    bool
 }
 
 type providersRegistration struct {
@@ -68,6 +70,16 @@ func (p *providersRegistration) Register(
 	sort.Sort(p)
 }
 
+// RegisterAppFinalizer marks the provider registered with the given name as one whose
+// ClowderProvider implements AppFinalizer, so that it is set up when a ClowdApp is deleted.
+func (p *providersRegistration) RegisterAppFinalizer(name string) {
+	for i := range p.Registry {
+		if p.Registry[i].Name == name {
+			p.Registry[i].FinalizesApps = true
+		}
+	}
+}
+
 // ProvidersRegistration is an instance of the provider registration system. It is responsible for
 // adding new providers to the registry so that they can be executed in the correct order.
 var ProvidersRegistration providersRegistration
@@ -84,6 +96,20 @@ type Provider struct {
 	Log       logr.Logger
 	Config    *config.AppConfig
 	HashCache *hashcache.HashCache
+	// RequeueAfter, when set, receives how long until the object being reconciled has to be
+	// reconciled again, as asked for by the providers.
+	RequeueAfter *time.Duration
+}
+
+// Requeue asks for the object being reconciled to be reconciled again after the given duration,
+// keeping the earliest time any provider asked for.
+func (prov *Provider) Requeue(after time.Duration) {
+	if prov.RequeueAfter == nil {
+		return
+	}
+	if *prov.RequeueAfter == 0 || after < *prov.RequeueAfter {
+		*prov.RequeueAfter = after
+	}
 }
 
 func (prov *Provider) GetClient() client.Client {
@@ -127,6 +153,13 @@ type ClowderProvider interface {
 	GetConfig() *config.AppConfig
 }
 
+// AppFinalizer is an optional interface for a ClowderProvider which has to clean up after a
+// ClowdApp when it is deleted, for anything which is not removed along with the objects owned by
+// the ClowdApp.
+type AppFinalizer interface {
+	FinalizeApp(app *crd.ClowdApp) error
+}
+
 // StrPtr returns a pointer to a string.
 func StrPtr(s string) *string {
 	return &s
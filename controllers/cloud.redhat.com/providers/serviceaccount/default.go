@@ -114,11 +114,11 @@ func (sa *serviceaccountProvider) Provide(app *crd.ClowdApp) error {
 	}
 
 	for _, dep := range app.Spec.Deployments {
-		d := &apps.Deployment{}
 		innerDeployment := dep
 		nn := app.GetDeploymentNamespacedName(&innerDeployment)
 
-		if err := sa.Cache.Get(deployment.CoreDeployment, d, nn); err != nil {
+		w, err := deployment.GetWorkload(sa.Cache, app, &innerDeployment)
+		if err != nil {
 			return err
 		}
 
@@ -128,8 +128,8 @@ func (sa *serviceaccountProvider) Provide(app *crd.ClowdApp) error {
 			return err
 		}
 
-		d.Spec.Template.Spec.ServiceAccountName = nn.Name
-		if err := sa.Cache.Update(deployment.CoreDeployment, d); err != nil {
+		w.Template.Spec.ServiceAccountName = nn.Name
+		if err := w.Update(sa.Cache); err != nil {
 			return err
 		}
 
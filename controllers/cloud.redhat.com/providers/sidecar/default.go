@@ -8,7 +8,6 @@ import (
 	cronjobProvider "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/cronjob"
 	deployProvider "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/deployment"
 
-	apps "k8s.io/api/apps/v1"
 	batch "k8s.io/api/batch/v1"
 	core "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -29,9 +28,8 @@ func (sc *sidecarProvider) EnvProvide() error {
 func (sc *sidecarProvider) Provide(app *crd.ClowdApp) error {
 	for _, deployment := range app.Spec.Deployments {
 		innerDeployment := deployment
-		d := &apps.Deployment{}
-
-		if err := sc.Cache.Get(deployProvider.CoreDeployment, d, app.GetDeploymentNamespacedName(&innerDeployment)); err != nil {
+		w, err := deployProvider.GetWorkload(sc.Cache, app, &innerDeployment)
+		if err != nil {
 			return err
 		}
 
@@ -41,15 +39,18 @@ func (sc *sidecarProvider) Provide(app *crd.ClowdApp) error {
 				if sidecar.Enabled && sc.Env.Spec.Providers.Sidecars.TokenRefresher.Enabled {
 					cont := getTokenRefresher(app.Name)
 					if cont != nil {
-						d.Spec.Template.Spec.Containers = append(d.Spec.Template.Spec.Containers, *cont)
+						w.Template.Spec.Containers = append(w.Template.Spec.Containers, *cont)
 					}
 				}
 			default:
-				return fmt.Errorf("%s is not a valid sidecar name", sidecar.Name)
+				// User defined sidecars are rendered by the deployment and cronjob providers
+				if sidecar.Image == "" {
+					return fmt.Errorf("%s is not a valid sidecar name", sidecar.Name)
+				}
 			}
 		}
 
-		if err := sc.Cache.Update(deployProvider.CoreDeployment, d); err != nil {
+		if err := w.Update(sc.Cache); err != nil {
 			return err
 		}
 	}
@@ -75,7 +76,10 @@ func (sc *sidecarProvider) Provide(app *crd.ClowdApp) error {
 					}
 				}
 			default:
-				return fmt.Errorf("%s is not a valid sidecar name", sidecar.Name)
+				// User defined sidecars are rendered by the deployment and cronjob providers
+				if sidecar.Image == "" {
+					return fmt.Errorf("%s is not a valid sidecar name", sidecar.Name)
+				}
 			}
 		}
 
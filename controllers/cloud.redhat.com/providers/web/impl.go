@@ -41,9 +41,8 @@ func makeService(cache *rc.ObjectCache, deployment *crd.Deployment, app *crd.Clo
 		return err
 	}
 
-	d := &apps.Deployment{}
-
-	if err := cache.Get(deployProvider.CoreDeployment, d, app.GetDeploymentNamespacedName(deployment)); err != nil {
+	w, err := deployProvider.GetWorkload(cache, app, deployment)
+	if err != nil {
 		return err
 	}
 
@@ -96,7 +95,7 @@ func makeService(cache *rc.ObjectCache, deployment *crd.Deployment, app *crd.Clo
 		}
 
 		if privatePort == 0 {
-			privatePort = 10000
+			privatePort = crd.DefaultPrivatePort
 		}
 
 		webPort := core.ServicePort{
@@ -157,20 +156,20 @@ func makeService(cache *rc.ObjectCache, deployment *crd.Deployment, app *crd.Clo
 			if err := generateEnvoyConfigMap(cache, nn, app, pub, priv, pubPort, privPort); err != nil {
 				return err
 			}
-			populateSideCar(d, nn.Name, env.Spec.Providers.Web.TLS.Port, env.Spec.Providers.Web.TLS.PrivatePort, pub, priv)
+			populateSideCar(w.Template, nn.Name, env.Spec.Providers.Web.TLS.Port, env.Spec.Providers.Web.TLS.PrivatePort, pub, priv)
 			setServiceTLSAnnotations(s, nn.Name)
 		}
 	}
 
 	utils.MakeService(s, nn, map[string]string{"pod": nn.Name}, servicePorts, app, env.IsNodePort())
 
-	d.Spec.Template.Spec.Containers[0].Ports = containerPorts
+	w.Template.Spec.Containers[0].Ports = containerPorts
 
 	if err := cache.Update(CoreService, s); err != nil {
 		return err
 	}
 
-	return cache.Update(deployProvider.CoreDeployment, d)
+	return w.Update(cache)
 }
 
 func generateEnvoyConfigMap(cache *rc.ObjectCache, nn types.NamespacedName, app *crd.ClowdApp, pub bool, priv bool, pubPort uint32, privPort uint32) error {
@@ -200,7 +199,7 @@ func generateEnvoyConfigMap(cache *rc.ObjectCache, nn types.NamespacedName, app
 	return cache.Update(CoreEnvoyConfigMap, cm)
 }
 
-func populateSideCar(d *apps.Deployment, name string, port int32, privatePort int32, pub bool, priv bool) {
+func populateSideCar(t *core.PodTemplateSpec, name string, port int32, privatePort int32, pub bool, priv bool) {
 	ports := []core.ContainerPort{}
 	if pub {
 		ports = append(ports, core.ContainerPort{
@@ -255,13 +254,13 @@ func populateSideCar(d *apps.Deployment, name string, port int32, privatePort in
 		VolumeSource: core.VolumeSource{
 			ConfigMap: &core.ConfigMapVolumeSource{
 				LocalObjectReference: core.LocalObjectReference{
-					Name: envoyConfigName(d.Name),
+					Name: envoyConfigName(name),
 				},
 			},
 		},
 	}
-	d.Spec.Template.Spec.Containers = append(d.Spec.Template.Spec.Containers, container)
-	d.Spec.Template.Spec.Volumes = append(d.Spec.Template.Spec.Volumes, envoyConfigVol, envoyTLSVol)
+	t.Spec.Containers = append(t.Spec.Containers, container)
+	t.Spec.Volumes = append(t.Spec.Volumes, envoyConfigVol, envoyTLSVol)
 }
 
 func setServiceTLSAnnotations(s *core.Service, name string) {
@@ -184,7 +184,7 @@ func (web *localWebProvider) Provide(app *crd.ClowdApp) error {
 	web.Config.PublicPort = utils.IntPtr(int(web.Env.Spec.Providers.Web.Port))
 	privatePort := web.Env.Spec.Providers.Web.PrivatePort
 	if privatePort == 0 {
-		privatePort = 10000
+		privatePort = crd.DefaultPrivatePort
 	}
 	web.Config.PrivatePort = utils.IntPtr(int(privatePort))
 
@@ -232,23 +232,23 @@ func (web *localWebProvider) Provide(app *crd.ClowdApp) error {
 		h.Write([]byte(jsonData))
 		hash := fmt.Sprintf("%x", h.Sum(nil))
 
-		d := &apps.Deployment{}
 		dnn := app.GetDeploymentNamespacedName(&innerDeployment)
-		if err := web.Cache.Get(provDeploy.CoreDeployment, d, dnn); err != nil {
+		w, err := provDeploy.GetWorkload(web.Cache, app, &innerDeployment)
+		if err != nil {
 			return err
 		}
 
 		if web.Env.Spec.Providers.Web.TLS.Enabled {
-			provutils.AddCertVolume(&d.Spec.Template.Spec, dnn.Name)
+			provutils.AddCertVolume(&w.Template.Spec, dnn.Name)
 		}
 
 		annotations := map[string]string{
 			"clowder/authsidecar-confighash": hash,
 		}
 
-		utils.UpdateAnnotations(&d.Spec.Template, annotations)
+		utils.UpdateAnnotations(w.Template, annotations)
 
-		if err := web.Cache.Update(provDeploy.CoreDeployment, d); err != nil {
+		if err := w.Update(web.Cache); err != nil {
 			return err
 		}
 
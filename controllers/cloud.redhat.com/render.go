@@ -0,0 +1,213 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	crd "github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1alpha1"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/config"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/errors"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/hashcache"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers"
+	rc "github.com/RedHatInsights/rhc-osdk-utils/resourceCache"
+	"github.com/RedHatInsights/rhc-osdk-utils/utils"
+	"github.com/go-logr/logr"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+)
+
+// RenderResult holds the output of an offline render of a ClowdEnvironment
+// and its ClowdApps.
+type RenderResult struct {
+	// Objects are the resources the providers would apply, sorted by kind,
+	// namespace and name.
+	Objects []client.Object
+	// Configs are the rendered cdappconfig for each ClowdApp, keyed by
+	// <namespace>/<name>.
+	Configs map[string]*config.AppConfig
+}
+
+// recordingClient passes all calls through to the wrapped client and keeps
+// the last written copy of every object that was created, updated or
+// patched.
+type recordingClient struct {
+	client.Client
+	objects map[string]client.Object
+}
+
+func (r *recordingClient) record(obj client.Object) {
+	gvk, err := utils.GetKindFromObj(Scheme, obj)
+	if err != nil {
+		return
+	}
+	key := fmt.Sprintf("%s/%s/%s", gvk.String(), obj.GetNamespace(), obj.GetName())
+	r.objects[key] = obj.DeepCopyObject().(client.Object)
+}
+
+func (r *recordingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if err := r.Client.Create(ctx, obj, opts...); err != nil {
+		return err
+	}
+	r.record(obj)
+	return nil
+}
+
+func (r *recordingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if err := r.Client.Update(ctx, obj, opts...); err != nil {
+		return err
+	}
+	r.record(obj)
+	return nil
+}
+
+func (r *recordingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if err := r.Client.Patch(ctx, obj, patch, opts...); err != nil {
+		return err
+	}
+	r.record(obj)
+	return nil
+}
+
+// Render runs the environment and app providers against an in memory client
+// and returns the resources that would be applied to the cluster, along with
+// the cdappconfig for each app. No cluster is needed, but providers which
+// wait on the state of other operators, for example the Kafka operator mode,
+// will not be able to complete.
+func Render(ctx context.Context, log logr.Logger, env *crd.ClowdEnvironment, apps []*crd.ClowdApp) (*RenderResult, error) {
+	if env.Spec.TargetNamespace != "" {
+		env.Status.TargetNamespace = env.Spec.TargetNamespace
+	} else if env.Status.TargetNamespace == "" {
+		env.Status.TargetNamespace = env.GenerateTargetNamespace()
+	}
+
+	namespaces := map[string]bool{env.Status.TargetNamespace: true}
+	initObjs := []client.Object{env}
+
+	for _, app := range apps {
+		if app.Spec.EnvName != env.Name {
+			return nil, errors.NewClowderError(fmt.Sprintf("app %s/%s is in env %s, not %s", app.Namespace, app.Name, app.Spec.EnvName, env.Name))
+		}
+		app.Default()
+		namespaces[app.Namespace] = true
+		initObjs = append(initObjs, app)
+	}
+
+	for ns := range namespaces {
+		initObjs = append(initObjs, &core.Namespace{ObjectMeta: metav1.ObjectMeta{Name: ns}})
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(Scheme).
+		WithObjects(initObjs...).
+		WithIndex(&crd.ClowdApp{}, "spec.envName", func(o client.Object) []string {
+			return []string{o.(*crd.ClowdApp).Spec.EnvName}
+		}).
+		Build()
+
+	recorder := &recordingClient{Client: fakeClient, objects: map[string]client.Object{}}
+	hashCache := hashcache.NewHashCache()
+
+	cacheConfig := rc.NewCacheConfig(Scheme, nil, ProtectedGVKs, rc.Options{StrictGVK: true, DebugOptions: DebugOptions, Ordering: applyOrder})
+
+	envCache := rc.NewObjectCache(ctx, recorder, &log, cacheConfig)
+	envProvider := providers.Provider{
+		Ctx:    ctx,
+		Client: recorder,
+		Env:    env,
+		Cache:  &envCache,
+		Log:    log,
+	}
+	if err := runProvidersForEnv(log, envProvider); err != nil {
+		return nil, err
+	}
+	if err := envCache.ApplyAll(); err != nil {
+		return nil, err
+	}
+
+	result := &RenderResult{Configs: map[string]*config.AppConfig{}}
+
+	for _, app := range apps {
+		appCache := rc.NewObjectCache(ctx, recorder, &log, cacheConfig)
+		r := ClowdAppReconciliation{
+			ctx:       ctx,
+			client:    recorder,
+			log:       &log,
+			app:       app,
+			env:       env,
+			cache:     &appCache,
+			config:    &config.AppConfig{},
+			hashCache: &hashCache,
+		}
+		provider := providers.Provider{
+			Client:    recorder,
+			Ctx:       ctx,
+			Env:       env,
+			Cache:     &appCache,
+			Log:       log,
+			Config:    r.config,
+			HashCache: &hashCache,
+		}
+		if err := r.runProvidersImplementation(&provider); err != nil {
+			return nil, errors.Wrap(fmt.Sprintf("app %s/%s", app.Namespace, app.Name), err)
+		}
+		if err := appCache.ApplyAll(); err != nil {
+			return nil, errors.Wrap(fmt.Sprintf("app %s/%s", app.Namespace, app.Name), err)
+		}
+		result.Configs[fmt.Sprintf("%s/%s", app.Namespace, app.Name)] = r.config
+	}
+
+	keys := make([]string, 0, len(recorder.objects))
+	for key := range recorder.objects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		obj := recorder.objects[key]
+		gvk, _ := utils.GetKindFromObj(Scheme, obj)
+		obj.GetObjectKind().SetGroupVersionKind(gvk)
+		obj.SetResourceVersion("")
+		result.Objects = append(result.Objects, obj)
+	}
+
+	return result, nil
+}
+
+// WriteRender writes the objects of a RenderResult as a multi document YAML
+// stream, followed by the cdappconfig of each app. The cdappconfig documents
+// are JSON, so the whole stream can still be read as YAML.
+func WriteRender(out io.Writer, result *RenderResult) error {
+	for _, obj := range result.Objects {
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(out, "---\n%s", data); err != nil {
+			return err
+		}
+	}
+
+	names := make([]string, 0, len(result.Configs))
+	for name := range result.Configs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		data, err := json.MarshalIndent(result.Configs[name], "", "  ")
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(out, "---\n# cdappconfig.json for ClowdApp %s\n%s\n", name, data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
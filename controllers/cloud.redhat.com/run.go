@@ -19,6 +19,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	crd "github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1alpha1"
+	crdv1beta1 "github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1beta1"
 	core "k8s.io/api/core/v1"
 	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -46,6 +47,7 @@ func init() {
 	secretCompare, _ = utils.GetKindFromObj(Scheme, &core.Secret{})
 	utilruntime.Must(clientgoscheme.AddToScheme(Scheme))
 	utilruntime.Must(crd.AddToScheme(Scheme))
+	utilruntime.Must(crdv1beta1.AddToScheme(Scheme))
 	utilruntime.Must(strimzi.AddToScheme(Scheme))
 	utilruntime.Must(cyndi.AddToScheme(Scheme))
 	utilruntime.Must(keda.AddToScheme(Scheme))
@@ -166,6 +168,22 @@ func setupWebhooks(mgr manager.Manager, enableWebHooks bool) error {
 			setupLog.Error(err, "unable to create webhook", "webhook", "Captain")
 			return err
 		}
+		if err := (&crd.ClowdEnvironment{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ClowdEnvironment")
+			return err
+		}
+		if err := (&crdv1beta1.ClowdApp{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ClowdApp")
+			return err
+		}
+		if err := (&crdv1beta1.ClowdEnvironment{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ClowdEnvironment")
+			return err
+		}
+		if err := (&crdv1beta1.ClowdJobInvocation{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ClowdJobInvocation")
+			return err
+		}
 		mgr.GetWebhookServer().Register(
 			"/mutate-pod",
 			&webhook.Admission{
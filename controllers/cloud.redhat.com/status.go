@@ -12,10 +12,15 @@ import (
 	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/clowderconfig"
 	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/errors"
 	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/object"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/database"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/kafka"
+	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/providers/migrations"
 	strimzi "github.com/RedHatInsights/strimzi-client-go/apis/kafka.strimzi.io/v1beta2"
 	apps "k8s.io/api/apps/v1"
+	batch "k8s.io/api/batch/v1"
 	core "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -39,6 +44,20 @@ func deploymentStatusChecker(deployment apps.Deployment) bool {
 	return false
 }
 
+func statefulSetStatusChecker(statefulSet apps.StatefulSet) bool {
+	if statefulSet.Generation > statefulSet.Status.ObservedGeneration {
+		// The status on this resource needs to update
+		return false
+	}
+
+	replicas := int32(1)
+	if statefulSet.Spec.Replicas != nil {
+		replicas = *statefulSet.Spec.Replicas
+	}
+
+	return statefulSet.Status.ReadyReplicas >= replicas && statefulSet.Status.UpdatedReplicas >= replicas
+}
+
 func kafkaStatusChecker(kafka strimzi.Kafka) bool {
 	// nil checks needed since these are all pointers in strimzi-client-go
 	if kafka.Status == nil {
@@ -106,6 +125,7 @@ func countDeployments(ctx context.Context, pClient client.Client, o object.Clowd
 	var msg = ""
 
 	deployments := []apps.Deployment{}
+	statefulSets := []apps.StatefulSet{}
 	for _, namespace := range namespaces {
 		opts := []client.ListOption{
 			client.InNamespace(namespace),
@@ -116,6 +136,13 @@ func countDeployments(ctx context.Context, pClient client.Client, o object.Clowd
 			return 0, 0, "", err
 		}
 		deployments = append(deployments, tmpDeployments.Items...)
+
+		tmpStatefulSets := apps.StatefulSetList{}
+		err = pClient.List(ctx, &tmpStatefulSets, opts...)
+		if err != nil {
+			return 0, 0, "", err
+		}
+		statefulSets = append(statefulSets, tmpStatefulSets.Items...)
 	}
 
 	// filter for resources owned by the ClowdObject and check their status
@@ -133,6 +160,21 @@ func countDeployments(ctx context.Context, pClient client.Client, o object.Clowd
 		}
 	}
 
+	// StatefulSets are counted alongside deployments as they back ClowdApp deployments too
+	for _, statefulSet := range statefulSets {
+		for _, owner := range statefulSet.GetOwnerReferences() {
+			if owner.UID == o.GetUID() {
+				managedDeployments++
+				if ok := statefulSetStatusChecker(statefulSet); ok {
+					readyDeployments++
+				} else {
+					brokenDeployments = append(brokenDeployments, fmt.Sprintf("%s/%s", statefulSet.Name, statefulSet.Namespace))
+				}
+				break
+			}
+		}
+	}
+
 	if len(brokenDeployments) > 0 {
 		sort.Strings(brokenDeployments)
 		msg = fmt.Sprintf("broken deployments: [%s]", strings.Join(brokenDeployments, ", "))
@@ -141,6 +183,147 @@ func countDeployments(ctx context.Context, pClient client.Client, o object.Clowd
 	return managedDeployments, readyDeployments, msg, nil
 }
 
+func lastDeploymentConditionMessage(conditions []apps.DeploymentCondition) string {
+	var last *apps.DeploymentCondition
+	for i := range conditions {
+		if last == nil || last.LastUpdateTime.Before(&conditions[i].LastUpdateTime) {
+			last = &conditions[i]
+		}
+	}
+	if last == nil {
+		return ""
+	}
+	return last.Message
+}
+
+func lastStatefulSetConditionMessage(conditions []apps.StatefulSetCondition) string {
+	var last *apps.StatefulSetCondition
+	for i := range conditions {
+		if last == nil || last.LastTransitionTime.Before(&conditions[i].LastTransitionTime) {
+			last = &conditions[i]
+		}
+	}
+	if last == nil {
+		return ""
+	}
+	return last.Message
+}
+
+func templateImage(template *core.PodTemplateSpec) string {
+	// The application container is always the first one, sidecars are appended after it
+	if len(template.Spec.Containers) == 0 {
+		return ""
+	}
+	return template.Spec.Containers[0].Image
+}
+
+func isOwnedBy(owners []v1.OwnerReference, o object.ClowdObject) bool {
+	for _, owner := range owners {
+		if owner.UID == o.GetUID() {
+			return true
+		}
+	}
+	return false
+}
+
+func getDeploymentStatuses(ctx context.Context, pClient client.Client, o object.ClowdObject, namespaces []string) ([]crd.DeploymentStatus, error) {
+	statuses := []crd.DeploymentStatus{}
+
+	for _, namespace := range namespaces {
+		opts := []client.ListOption{
+			client.InNamespace(namespace),
+		}
+
+		deployments := apps.DeploymentList{}
+		if err := pClient.List(ctx, &deployments, opts...); err != nil {
+			return nil, err
+		}
+
+		for _, deployment := range deployments.Items {
+			if !isOwnedBy(deployment.GetOwnerReferences(), o) {
+				continue
+			}
+			desired := int32(1)
+			if deployment.Spec.Replicas != nil {
+				desired = *deployment.Spec.Replicas
+			}
+			statuses = append(statuses, crd.DeploymentStatus{
+				Name:               deployment.Name,
+				Kind:               "Deployment",
+				Ready:              deploymentStatusChecker(deployment),
+				DesiredReplicas:    desired,
+				ReadyReplicas:      deployment.Status.ReadyReplicas,
+				UpdatedReplicas:    deployment.Status.UpdatedReplicas,
+				Image:              templateImage(&deployment.Spec.Template),
+				Message:            lastDeploymentConditionMessage(deployment.Status.Conditions),
+				ObservedGeneration: deployment.Status.ObservedGeneration,
+			})
+		}
+
+		statefulSets := apps.StatefulSetList{}
+		if err := pClient.List(ctx, &statefulSets, opts...); err != nil {
+			return nil, err
+		}
+
+		for _, statefulSet := range statefulSets.Items {
+			if !isOwnedBy(statefulSet.GetOwnerReferences(), o) {
+				continue
+			}
+			desired := int32(1)
+			if statefulSet.Spec.Replicas != nil {
+				desired = *statefulSet.Spec.Replicas
+			}
+			statuses = append(statuses, crd.DeploymentStatus{
+				Name:               statefulSet.Name,
+				Kind:               "StatefulSet",
+				Ready:              statefulSetStatusChecker(statefulSet),
+				DesiredReplicas:    desired,
+				ReadyReplicas:      statefulSet.Status.ReadyReplicas,
+				UpdatedReplicas:    statefulSet.Status.UpdatedReplicas,
+				Image:              templateImage(&statefulSet.Spec.Template),
+				Message:            lastStatefulSetConditionMessage(statefulSet.Status.Conditions),
+				ObservedGeneration: statefulSet.Status.ObservedGeneration,
+			})
+		}
+	}
+
+	// sorted so that the status does not flap between reconciliations
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+
+	return statuses, nil
+}
+
+func getCronJobStatuses(ctx context.Context, pClient client.Client, o object.ClowdObject, namespaces []string) ([]crd.CronJobStatus, error) {
+	statuses := []crd.CronJobStatus{}
+
+	for _, namespace := range namespaces {
+		cronJobs := batch.CronJobList{}
+		if err := pClient.List(ctx, &cronJobs, client.InNamespace(namespace)); err != nil {
+			return nil, err
+		}
+
+		for _, cronJob := range cronJobs.Items {
+			if !isOwnedBy(cronJob.GetOwnerReferences(), o) {
+				continue
+			}
+			statuses = append(statuses, crd.CronJobStatus{
+				Name:               cronJob.Name,
+				LastScheduleTime:   cronJob.Status.LastScheduleTime,
+				LastSuccessfulTime: cronJob.Status.LastSuccessfulTime,
+				Active:             int32(len(cronJob.Status.Active)),
+			})
+		}
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+
+	return statuses, nil
+}
+
 func countKafkas(ctx context.Context, pClient client.Client, o object.ClowdObject, namespaces []string) (int32, int32, string, error) {
 	var managedKafkas int32
 	var readyKafka int32
@@ -346,15 +529,123 @@ func GetEnvResourceFigures(ctx context.Context, client client.Client, o *crd.Clo
 	return deploymentStats, msg, nil
 }
 
-func GetAppResourceStatus(ctx context.Context, client client.Client, o *crd.ClowdApp) (bool, error) {
-	stats, _, err := GetAppResourceFigures(ctx, client, o)
+func GetAppResourceStatus(ctx context.Context, client client.Client, o *crd.ClowdApp) (bool, string, error) {
+	env, err := getAppEnv(ctx, client, o)
 	if err != nil {
-		return false, err
+		return false, "", err
 	}
-	if stats.ManagedDeployments == stats.ReadyDeployments {
-		return true, nil
+	return getAppResourceStatus(ctx, client, o, env)
+}
+
+func getAppResourceStatus(ctx context.Context, client client.Client, o *crd.ClowdApp, env *crd.ClowdEnvironment) (bool, string, error) {
+	stats, msg, err := GetAppResourceFigures(ctx, client, o)
+	if err != nil {
+		return false, msg, err
 	}
-	return false, nil
+	if stats.ManagedDeployments != stats.ReadyDeployments {
+		return false, msg, nil
+	}
+	// The deployments are only ready to be used once the database is seeded
+	seeded, seedMsg, err := getDatabaseSeedStatus(ctx, client, o, env)
+	if err != nil {
+		return false, msg, err
+	}
+	if !seeded {
+		return false, seedMsg, nil
+	}
+	// Until the migrations are applied the deployments still run the previous image
+	if applied, migrationsMsg := getMigrationsStatus(o); !applied {
+		return false, migrationsMsg, nil
+	}
+	return true, msg, nil
+}
+
+// getMigrationsStatus returns whether the migrations of the app have been
+// applied for the current image, as recorded by the migrations provider. Apps
+// without migrations are always migrated.
+func getMigrationsStatus(o *crd.ClowdApp) (bool, string) {
+	if o.Spec.Migrations == nil {
+		return true, ""
+	}
+
+	status := o.Status.Migrations
+	switch {
+	case status == nil:
+		return false, "migrations have not started"
+	case status.Phase == migrations.PhaseSucceeded:
+		return true, ""
+	case status.Phase == migrations.PhaseFailed:
+		return false, fmt.Sprintf("migrations failed: %s", status.Message)
+	default:
+		return false, "migrations in progress"
+	}
+}
+
+// isKafkaTopicValidationEnabled returns whether the KafkaTopics of the app are compared with the
+// spec it requests for them, which they only are for apps with topics on an app-interface cluster
+// of an existing environment.
+func isKafkaTopicValidationEnabled(o *crd.ClowdApp, env *crd.ClowdEnvironment) bool {
+	if env == nil {
+		return false
+	}
+
+	for _, topic := range o.Spec.KafkaTopics {
+		if kafka.GetClusterMode(env, topic.Cluster) == "app-interface" {
+			return true
+		}
+	}
+	return false
+}
+
+// getAppEnv returns the ClowdEnvironment of the app, or nil when it does not exist so that the
+// status of apps whose environment is missing can still be written.
+func getAppEnv(ctx context.Context, pClient client.Client, o *crd.ClowdApp) (*crd.ClowdEnvironment, error) {
+	env := &crd.ClowdEnvironment{}
+	if err := pClient.Get(ctx, types.NamespacedName{Name: o.Spec.EnvName}, env); err != nil {
+		if k8serr.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap("get env: ", err)
+	}
+	return env, nil
+}
+
+// getDatabaseSeedStatus returns whether the database of the app has been
+// seeded. Apps without a seed, without an environment, or in an environment
+// whose database mode does not seed, are always seeded.
+func getDatabaseSeedStatus(ctx context.Context, pClient client.Client, o *crd.ClowdApp, env *crd.ClowdEnvironment) (bool, string, error) {
+	if o.Spec.Database.Seed == nil || env == nil {
+		return true, "", nil
+	}
+
+	if mode := env.Spec.Providers.Database.Mode; mode != "local" && mode != "shared" {
+		return true, "", nil
+	}
+
+	job := &batch.Job{}
+	nn := types.NamespacedName{
+		Name:      database.GetSeedJobName(o),
+		Namespace: o.Namespace,
+	}
+
+	if err := pClient.Get(ctx, nn, job); err != nil {
+		if k8serr.IsNotFound(err) {
+			return false, "database seeding has not started", nil
+		}
+		return false, "", errors.Wrap("get seed job: ", err)
+	}
+
+	if job.Status.Succeeded > 0 {
+		return true, "", nil
+	}
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batch.JobFailed && condition.Status == core.ConditionTrue {
+			return false, fmt.Sprintf("database seeding failed: %s", condition.Message), nil
+		}
+	}
+
+	return false, "database seeding in progress", nil
 }
 
 // SetAppResourceStatus the status on the passed ClowdObject interface.
@@ -367,6 +658,8 @@ func SetAppResourceStatus(ctx context.Context, client client.Client, o *crd.Clow
 	status := o.GetDeploymentStatus()
 	status.ManagedDeployments = stats.ManagedDeployments
 	status.ReadyDeployments = stats.ReadyDeployments
+	status.DeploymentStatuses = stats.DeploymentStatuses
+	status.CronJobStatuses = stats.CronJobStatuses
 
 	return nil
 }
@@ -375,7 +668,7 @@ func GetAppResourceFigures(ctx context.Context, client client.Client, o *crd.Clo
 
 	var totalManagedDeployments int32
 	var totalReadyDeployments int32
-	var msgs []string
+	var brokenDeployments []string
 
 	deploymentStats := crd.AppResourceStatus{}
 
@@ -384,19 +677,38 @@ func GetAppResourceFigures(ctx context.Context, client client.Client, o *crd.Clo
 		return crd.AppResourceStatus{}, "", errors.Wrap("get namespaces: ", err)
 	}
 
-	managedDeployments, readyDeployments, msg, err := countDeployments(ctx, client, o, namespaces)
+	deploymentStatuses, err := getDeploymentStatuses(ctx, client, o, namespaces)
 	if err != nil {
 		return crd.AppResourceStatus{}, "", errors.Wrap("count deploys: ", err)
 	}
-	totalManagedDeployments += managedDeployments
-	totalReadyDeployments += readyDeployments
-	if msg != "" {
-		msgs = append(msgs, msg)
+
+	for _, deploymentStatus := range deploymentStatuses {
+		totalManagedDeployments++
+		if deploymentStatus.Ready {
+			totalReadyDeployments++
+			continue
+		}
+		broken := fmt.Sprintf("%s %d/%d ready", deploymentStatus.Name, deploymentStatus.ReadyReplicas, deploymentStatus.DesiredReplicas)
+		if deploymentStatus.Message != "" {
+			broken = fmt.Sprintf("%s (%s)", broken, deploymentStatus.Message)
+		}
+		brokenDeployments = append(brokenDeployments, broken)
+	}
+
+	cronJobStatuses, err := getCronJobStatuses(ctx, client, o, namespaces)
+	if err != nil {
+		return crd.AppResourceStatus{}, "", errors.Wrap("list cronjobs: ", err)
+	}
+
+	msg := ""
+	if len(brokenDeployments) > 0 {
+		msg = fmt.Sprintf("broken deployments: [%s]", strings.Join(brokenDeployments, ", "))
 	}
 
-	msg = fmt.Sprintf("dependency failure: [%s]", strings.Join(msgs, ","))
 	deploymentStats.ManagedDeployments = totalManagedDeployments
 	deploymentStats.ReadyDeployments = totalReadyDeployments
+	deploymentStats.DeploymentStatuses = deploymentStatuses
+	deploymentStats.CronJobStatuses = cronJobStatuses
 	return deploymentStats, msg, nil
 }
 
@@ -488,7 +800,12 @@ func SetClowdAppConditions(ctx context.Context, client client.Client, o *crd.Clo
 		conditions = append(conditions, *condition)
 	}
 
-	deploymentStatus, err := GetAppResourceStatus(ctx, client, o)
+	env, err := getAppEnv(ctx, client, o)
+	if err != nil {
+		return err
+	}
+
+	deploymentStatus, msg, err := getAppResourceStatus(ctx, client, o, env)
 	if err != nil {
 		return err
 	}
@@ -497,6 +814,9 @@ func SetClowdAppConditions(ctx context.Context, client client.Client, o *crd.Clo
 
 	condition.Status = core.ConditionFalse
 	condition.Message = "Deployments are not yet ready"
+	if msg != "" {
+		condition.Message = fmt.Sprintf("Deployments are not yet ready: %s", msg)
+	}
 	if deploymentStatus {
 		condition.Status = core.ConditionTrue
 		condition.Message = "All managed deployments ready"
@@ -510,6 +830,63 @@ func SetClowdAppConditions(ctx context.Context, client client.Client, o *crd.Clo
 
 	conditions = append(conditions, *condition)
 
+	if o.Spec.Database.Seed != nil {
+		seeded, seedMsg, err := getDatabaseSeedStatus(ctx, client, o, env)
+		if err != nil {
+			return err
+		}
+
+		seedCondition := &clusterv1.Condition{}
+		seedCondition.Type = crd.DatabaseSeeded
+		seedCondition.Status = core.ConditionFalse
+		seedCondition.Message = seedMsg
+		if seeded {
+			seedCondition.Status = core.ConditionTrue
+			seedCondition.Message = "Database seeded"
+		}
+		seedCondition.LastTransitionTime = v1.Now()
+
+		conditions = append(conditions, *seedCondition)
+	} else {
+		cond.Delete(o, crd.DatabaseSeeded)
+	}
+
+	if o.Spec.Migrations != nil {
+		applied, migrationsMsg := getMigrationsStatus(o)
+
+		migrationsCondition := &clusterv1.Condition{}
+		migrationsCondition.Type = crd.MigrationsApplied
+		migrationsCondition.Status = core.ConditionFalse
+		migrationsCondition.Message = migrationsMsg
+		if applied {
+			migrationsCondition.Status = core.ConditionTrue
+			migrationsCondition.Message = "Migrations applied"
+		}
+		migrationsCondition.LastTransitionTime = v1.Now()
+
+		conditions = append(conditions, *migrationsCondition)
+	} else {
+		cond.Delete(o, crd.MigrationsApplied)
+	}
+
+	if isKafkaTopicValidationEnabled(o, env) {
+		topicsCondition := &clusterv1.Condition{}
+		topicsCondition.Type = crd.KafkaTopicsMatch
+		topicsCondition.Status = core.ConditionTrue
+		topicsCondition.Message = "KafkaTopics match the requested spec"
+		if len(o.Status.KafkaTopicMismatches) > 0 {
+			topicsCondition.Status = core.ConditionFalse
+			topicsCondition.Severity = clusterv1.ConditionSeverityWarning
+			topicsCondition.Reason = "KafkaTopicMismatch"
+			topicsCondition.Message = kafka.DescribeTopicMismatches(o.Status.KafkaTopicMismatches)
+		}
+		topicsCondition.LastTransitionTime = v1.Now()
+
+		conditions = append(conditions, *topicsCondition)
+	} else {
+		cond.Delete(o, crd.KafkaTopicsMatch)
+	}
+
 	for _, condition := range conditions {
 		innerCondition := condition
 		cond.Set(o, &innerCondition)
@@ -41,6 +41,7 @@ import (
 	ctrlzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	crd "github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1alpha1"
+	crdv1beta1 "github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1beta1"
 	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/clowderconfig"
 	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/config"
 	"github.com/RedHatInsights/rhc-osdk-utils/utils"
@@ -92,6 +93,9 @@ func (suite *TestSuite) SetupSuite() {
 	err = crd.AddToScheme(clientgoscheme.Scheme)
 	assert.NoError(suite.T(), err, "failed to add scheme")
 
+	err = crdv1beta1.AddToScheme(clientgoscheme.Scheme)
+	assert.NoError(suite.T(), err, "failed to add scheme")
+
 	err = strimzi.AddToScheme(clientgoscheme.Scheme)
 	assert.NoError(suite.T(), err, "failed to add scheme")
 
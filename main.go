@@ -36,6 +36,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	crd "github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1alpha1"
+	crdv1beta1 "github.com/RedHatInsights/clowder/apis/cloud.redhat.com/v1beta1"
 	controllers "github.com/RedHatInsights/clowder/controllers/cloud.redhat.com"
 	"github.com/RedHatInsights/clowder/controllers/cloud.redhat.com/clowderconfig"
 	"github.com/RedHatInsights/rhc-osdk-utils/logging"
@@ -50,6 +51,7 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(crd.AddToScheme(scheme))
+	utilruntime.Must(crdv1beta1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 